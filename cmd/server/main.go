@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
+	"hunter-seeker/internal/auth"
 	"hunter-seeker/internal/database"
 	"hunter-seeker/internal/handlers"
 
@@ -18,13 +23,19 @@ func main() {
 		port = "8080"
 	}
 
+	// HOST limits the interfaces the server listens on, such as 127.0.0.1; all by default
+	host := os.Getenv("HOST")
+
 	dbPath := os.Getenv("DB_PATH")
 	if dbPath == "" {
 		dbPath = "./data/jobs.db"
 	}
 
+	// DATABASE_URL stores data in PostgreSQL instead, so several servers can share it
+	databaseURL := os.Getenv("DATABASE_URL")
+
 	// Initialize database
-	db, err := database.New(dbPath)
+	db, err := openDatabase(databaseURL, dbPath)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
@@ -36,9 +47,18 @@ func main() {
 		log.Fatalf("Failed to initialize handlers: %v", err)
 	}
 
+	if err := h.ConfigureAuth(authConfig()); err != nil {
+		log.Fatalf("Failed to configure login: %v", err)
+	}
+
 	// Setup router
 	r := mux.NewRouter()
 
+	// Login
+	r.HandleFunc("/login", h.LoginPageHandler).Methods("GET")
+	r.HandleFunc("/login", h.LoginHandler).Methods("POST")
+	r.HandleFunc("/logout", h.LogoutHandler).Methods("POST")
+
 	// Web routes
 	r.HandleFunc("/", h.HomeHandler).Methods("GET")
 	r.HandleFunc("/add", h.AddJobHandler).Methods("GET")
@@ -47,11 +67,78 @@ func main() {
 	r.HandleFunc("/update/{id}", h.UpdateJobHandler).Methods("POST")
 	r.HandleFunc("/delete/{id}", h.DeleteJobHandler).Methods("POST")
 	r.HandleFunc("/filter", h.FilterHandler).Methods("GET")
+	r.HandleFunc("/search", h.SearchHandler).Methods("GET")
+	r.HandleFunc("/board", h.BoardHandler).Methods("GET")
+	r.HandleFunc("/edit/{id}/contacts", h.LinkJobContactHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/contacts/{contactID}/unlink", h.UnlinkJobContactHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/interviews", h.CreateInterviewHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/interviews/{interviewID}/update", h.UpdateInterviewHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/interviews/{interviewID}/delete", h.DeleteInterviewHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/attachments", h.UploadAttachmentHandler).Methods("POST")
+	r.HandleFunc("/edit/{id}/attachments/{attachmentID}/delete", h.DeleteAttachmentHandler).Methods("POST")
+	r.HandleFunc("/attachments/{id:[0-9]+}", h.DownloadAttachmentHandler).Methods("GET")
+	r.HandleFunc("/contacts", h.ContactsHandler).Methods("GET")
+	r.HandleFunc("/contacts/new", h.NewContactHandler).Methods("GET")
+	r.HandleFunc("/contacts/create", h.CreateContactHandler).Methods("POST")
+	r.HandleFunc("/contacts/{id}", h.ContactHandler).Methods("GET")
+	r.HandleFunc("/contacts/{id}/edit", h.EditContactHandler).Methods("GET")
+	r.HandleFunc("/contacts/{id}/update", h.UpdateContactHandler).Methods("POST")
+	r.HandleFunc("/contacts/{id}/delete", h.DeleteContactHandler).Methods("POST")
+	r.HandleFunc("/follow-ups", h.FollowUpsHandler).Methods("GET")
+	r.HandleFunc("/follow-ups/rules", h.SaveFollowUpRuleHandler).Methods("POST")
+	r.HandleFunc("/follow-ups/rules/{id}/delete", h.DeleteFollowUpRuleHandler).Methods("POST")
+	r.HandleFunc("/follow-ups/{id}/snooze", h.SnoozeFollowUpHandler).Methods("POST")
+	r.HandleFunc("/settings/statuses", h.StatusSettingsHandler).Methods("GET")
+	r.HandleFunc("/settings/statuses", h.CreateStatusHandler).Methods("POST")
+	r.HandleFunc("/settings/statuses/{id}/update", h.UpdateStatusHandler).Methods("POST")
+	r.HandleFunc("/settings/statuses/{id}/move", h.MoveStatusHandler).Methods("POST")
+	r.HandleFunc("/settings/statuses/{id}/delete", h.DeleteStatusHandler).Methods("POST")
+	r.HandleFunc("/admin/users", h.UsersHandler).Methods("GET")
+	r.HandleFunc("/admin/users", h.CreateUserHandler).Methods("POST")
+	r.HandleFunc("/admin/users/{id}/update", h.UpdateUserHandler).Methods("POST")
+	r.HandleFunc("/admin/users/{id}/delete", h.DeleteUserHandler).Methods("POST")
+	r.HandleFunc("/companies", h.CompaniesHandler).Methods("GET")
+	r.HandleFunc("/companies/{id}", h.CompanyHandler).Methods("GET")
+	r.HandleFunc("/companies/{id}/update", h.UpdateCompanyHandler).Methods("POST")
+	r.HandleFunc("/companies/{id}/aliases", h.AddCompanyAliasHandler).Methods("POST")
+	r.HandleFunc("/companies/{id}/aliases/{aliasID}/delete", h.RemoveCompanyAliasHandler).Methods("POST")
 	r.HandleFunc("/import-csv", h.ImportCSVHandler).Methods("GET")
 	r.HandleFunc("/process-csv", h.ProcessCSVHandler).Methods("POST")
+	r.HandleFunc("/import-csv/preview", h.PreviewCSVHandler).Methods("POST")
+	r.HandleFunc("/import-csv/confirm", h.ConfirmCSVHandler).Methods("POST")
+	r.HandleFunc("/export.csv", h.ExportCSVHandler).Methods("GET")
+	r.HandleFunc("/calendar.ics", h.CalendarFeedHandler).Methods("GET")
+	r.HandleFunc("/calendar/interviews/{id:[0-9]+}.ics", h.InterviewICSHandler).Methods("GET")
+	r.HandleFunc("/calendar/jobs/{id:[0-9]+}/follow-up.ics", h.FollowUpICSHandler).Methods("GET")
+	r.HandleFunc("/analytics", h.AnalyticsHandler).Methods("GET")
 
 	// API routes
 	r.HandleFunc("/api/stats", h.StatsHandler).Methods("GET")
+	r.HandleFunc("/api/analytics", h.APIAnalyticsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/jobs", h.APIListJobsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/jobs", h.APICreateJobHandler).Methods("POST")
+	r.HandleFunc("/api/v1/jobs/{id}", h.APIGetJobHandler).Methods("GET")
+	r.HandleFunc("/api/v1/jobs/{id}", h.APIUpdateJobHandler).Methods("PUT", "PATCH")
+	r.HandleFunc("/api/v1/jobs/{id}", h.APIDeleteJobHandler).Methods("DELETE")
+	r.HandleFunc("/api/v1/jobs/{id}/contacts", h.APIListJobContactsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/jobs/{id}/contacts/{contactID}", h.APILinkJobContactHandler).Methods("PUT", "DELETE")
+	r.HandleFunc("/api/v1/jobs/{id}/interviews", h.APIListJobInterviewsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/jobs/{id}/interviews", h.APICreateInterviewHandler).Methods("POST")
+	r.HandleFunc("/api/v1/interviews/{id}", h.APIGetInterviewHandler).Methods("GET")
+	r.HandleFunc("/api/v1/interviews/{id}", h.APIUpdateInterviewHandler).Methods("PUT", "PATCH")
+	r.HandleFunc("/api/v1/interviews/{id}", h.APIDeleteInterviewHandler).Methods("DELETE")
+	r.HandleFunc("/api/v1/follow-ups", h.APIFollowUpsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/statuses", h.APIListStatusesHandler).Methods("GET")
+	r.HandleFunc("/api/v1/tags", h.APIListTagsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/contacts", h.APIListContactsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/contacts", h.APICreateContactHandler).Methods("POST")
+	r.HandleFunc("/api/v1/contacts/{id}", h.APIGetContactHandler).Methods("GET")
+	r.HandleFunc("/api/v1/contacts/{id}", h.APIUpdateContactHandler).Methods("PUT", "PATCH")
+	r.HandleFunc("/api/v1/contacts/{id}", h.APIDeleteContactHandler).Methods("DELETE")
+	r.HandleFunc("/api/v1/companies", h.APIListCompaniesHandler).Methods("GET")
+	r.HandleFunc("/api/v1/companies/{id}", h.APIGetCompanyHandler).Methods("GET")
+	r.HandleFunc("/api/v1/companies/{id}", h.APIUpdateCompanyHandler).Methods("PUT", "PATCH")
+	r.HandleFunc("/api/v1/companies/{id}/aliases", h.APIAddCompanyAliasHandler).Methods("POST")
 
 	// Health check
 	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -63,7 +150,112 @@ func main() {
 	// Static files
 	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./web/static/"))))
 
-	log.Printf("Server starting on port %s", port)
-	log.Printf("Database: %s", dbPath)
-	log.Fatal(http.ListenAndServe(":"+port, r))
+	// Move long-silent applications to "No Response" now and every hour
+	go moveSilentApplications(db, time.Hour, time.Minute)
+
+	log.Printf("Server starting on %s:%s", host, port)
+	if databaseURL != "" {
+		// The URL may hold a password, so it is not logged
+		log.Printf("Database: PostgreSQL")
+	} else {
+		log.Printf("Database: %s", dbPath)
+	}
+	handler := handlers.Timeout(requestTimeout(), h.RequireLogin(h.CSRFProtect(r)))
+	log.Fatal(http.ListenAndServe(host+":"+port, handler))
+}
+
+// defaultRequestTimeout is how long a request may run without REQUEST_TIMEOUT
+const defaultRequestTimeout = 30 * time.Second
+
+// requestTimeout reads REQUEST_TIMEOUT, how long a request's database queries may run
+// before they are cancelled, such as 30s or 2m; 0 means no limit
+func requestTimeout() time.Duration {
+	timeout := os.Getenv("REQUEST_TIMEOUT")
+	if timeout == "" {
+		return defaultRequestTimeout
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		log.Fatalf("Invalid REQUEST_TIMEOUT %q: %v", timeout, err)
+	}
+	return duration
+}
+
+// openDatabase connects to the PostgreSQL database at databaseURL if one is given, or
+// else opens the SQLite file at dbPath. Attachments are kept beside dbPath either way.
+func openDatabase(databaseURL, dbPath string) (*database.DB, error) {
+	if databaseURL == "" {
+		return database.New(dbPath)
+	}
+	return database.NewPostgres(databaseURL, filepath.Join(filepath.Dir(dbPath), "attachments"))
+}
+
+// authConfig reads the optional login settings from the environment:
+// AUTH_PASSWORD or AUTH_PASSWORD_HASH (bcrypt) set the first admin's password, AUTH_REQUIRED=true
+// asks for one to be chosen on first run, and SECURE_COOKIES=true is for running behind HTTPS
+func authConfig() handlers.AuthConfig {
+	config := handlers.AuthConfig{
+		Required:      envBool("AUTH_REQUIRED"),
+		PasswordHash:  os.Getenv("AUTH_PASSWORD_HASH"),
+		SecureCookies: envBool("SECURE_COOKIES"),
+	}
+
+	if password := os.Getenv("AUTH_PASSWORD"); password != "" {
+		hash, err := auth.HashPassword(password)
+		if err != nil {
+			log.Fatalf("Failed to hash AUTH_PASSWORD: %v", err)
+		}
+		config.PasswordHash = hash
+	}
+
+	if ttl := os.Getenv("SESSION_TTL"); ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatalf("Invalid SESSION_TTL %q: %v", ttl, err)
+		}
+		config.SessionTTL = duration
+	}
+
+	return config
+}
+
+// envBool reads a true/false environment variable, treating anything unreadable as false
+func envBool(name string) bool {
+	value, _ := strconv.ParseBool(os.Getenv(name))
+	return value
+}
+
+// silentRetries is how many times a failed run of moveSilentApplications is retried
+// before it waits for the next interval
+const silentRetries = 3
+
+// moveSilentApplications applies the follow-up rules' no-response periods at every interval
+func moveSilentApplications(db database.Store, interval, retryDelay time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		moveSilentApplicationsOnce(db, retryDelay)
+		<-ticker.C
+	}
+}
+
+// moveSilentApplicationsOnce runs MoveSilentApplications, retrying after retryDelay while it
+// fails, and reports whether it succeeded. Each failure is logged.
+func moveSilentApplicationsOnce(db database.Store, retryDelay time.Duration) bool {
+	for attempt := 0; ; attempt++ {
+		moved, err := db.MoveSilentApplications(context.Background(), time.Now())
+		if err == nil {
+			if moved > 0 {
+				log.Printf("Moved %d silent application(s) to No Response", moved)
+			}
+			return true
+		}
+		if attempt == silentRetries {
+			log.Printf("Error moving silent applications, giving up until the next run: %v", err)
+			return false
+		}
+		log.Printf("Error moving silent applications, retrying in %v: %v", retryDelay, err)
+		time.Sleep(retryDelay)
+	}
 }
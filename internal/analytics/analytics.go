@@ -0,0 +1,249 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// workflow is what a report needs to know about the configured statuses
+type workflow struct {
+	// stages are the steps of the hiring pipeline used to measure funnel conversion:
+	// the statuses that are not closed, in workflow order. An application has reached
+	// a stage if it was ever in its status, or in a later stage's. Every application
+	// counts as reaching the first.
+	stages []string
+	// rejections are the statuses with the rejection role
+	rejections map[string]bool
+	// nonResponses are statuses that do not mean the company replied: the first,
+	// which applications start in, and the closed statuses other than rejections
+	nonResponses map[string]bool
+}
+
+func newWorkflow(statuses []*models.Status) *workflow {
+	w := &workflow{rejections: make(map[string]bool), nonResponses: make(map[string]bool)}
+	for i, status := range statuses {
+		switch {
+		case status.Role == models.RoleRejection:
+			w.rejections[status.Name] = true
+		case i == 0 || status.Category == models.CategoryClosed:
+			w.nonResponses[status.Name] = true
+		}
+		if status.Category != models.CategoryClosed {
+			w.stages = append(w.stages, status.Name)
+		}
+	}
+	return w
+}
+
+// Report holds the pipeline analytics for a set of job applications
+type Report struct {
+	From                      *time.Time         `json:"from,omitempty"`
+	To                        *time.Time         `json:"to,omitempty"`
+	TotalApplications         int                `json:"total_applications"`
+	Funnel                    []FunnelStage      `json:"funnel"`
+	MedianDaysToFirstResponse *float64           `json:"median_days_to_first_response"`
+	ResponsesMeasured         int                `json:"responses_measured"`
+	RejectionRate             float64            `json:"rejection_rate"`
+	ApplicationsPerWeek       []WeekCount        `json:"applications_per_week"`
+	CompanyRejections         []CompanyRejection `json:"company_rejections"`
+}
+
+// FunnelStage is the number of applications that reached a stage
+type FunnelStage struct {
+	Stage string `json:"stage"`
+	Count int    `json:"count"`
+	// ConversionRate is the share of the previous stage that reached this one
+	ConversionRate float64 `json:"conversion_rate"`
+	// OverallRate is the share of all applications that reached this stage
+	OverallRate float64 `json:"overall_rate"`
+}
+
+// WeekCount is the number of applications sent in the week starting on WeekStart (a Monday)
+type WeekCount struct {
+	WeekStart time.Time `json:"week_start"`
+	Count     int       `json:"count"`
+}
+
+// CompanyRejection summarizes outcomes at a single company
+type CompanyRejection struct {
+	Company       string  `json:"company"`
+	Applications  int     `json:"applications"`
+	Rejections    int     `json:"rejections"`
+	RejectionRate float64 `json:"rejection_rate"`
+}
+
+// Compute builds a report from job applications with their status history loaded,
+// using the configured statuses in workflow order. from and to are only recorded on
+// the report; jobs are expected to be filtered already.
+func Compute(jobs []*models.JobApplication, statuses []*models.Status, from, to time.Time) *Report {
+	w := newWorkflow(statuses)
+	report := &Report{
+		TotalApplications:   len(jobs),
+		Funnel:              make([]FunnelStage, len(w.stages)),
+		ApplicationsPerWeek: []WeekCount{},
+		CompanyRejections:   []CompanyRejection{},
+	}
+	if !from.IsZero() {
+		report.From = &from
+	}
+	if !to.IsZero() {
+		report.To = &to
+	}
+
+	stageCounts := make([]int, len(w.stages))
+	var responseDays []float64
+	var rejections int
+	weeks := make(map[time.Time]int)
+	companies := make(map[string]*CompanyRejection)
+
+	for _, job := range jobs {
+		for i := 0; i <= w.reachedStage(job) && i < len(w.stages); i++ {
+			stageCounts[i]++
+		}
+
+		if days, ok := w.daysToFirstResponse(job); ok {
+			responseDays = append(responseDays, days)
+		}
+
+		rejected := w.rejections[job.Status]
+		if rejected {
+			rejections++
+		}
+
+		weeks[weekStart(job.DateApplied)]++
+
+		key := models.NormalizeCompanyName(job.Company)
+		company, ok := companies[key]
+		if !ok {
+			company = &CompanyRejection{Company: strings.TrimSpace(job.Company)}
+			companies[key] = company
+		}
+		company.Applications++
+		if rejected {
+			company.Rejections++
+		}
+	}
+
+	for i, stage := range w.stages {
+		report.Funnel[i] = FunnelStage{Stage: stage, Count: stageCounts[i]}
+		report.Funnel[i].OverallRate = rate(stageCounts[i], len(jobs))
+		if i == 0 {
+			report.Funnel[i].ConversionRate = report.Funnel[i].OverallRate
+		} else {
+			report.Funnel[i].ConversionRate = rate(stageCounts[i], stageCounts[i-1])
+		}
+	}
+
+	report.ResponsesMeasured = len(responseDays)
+	if len(responseDays) > 0 {
+		m := median(responseDays)
+		report.MedianDaysToFirstResponse = &m
+	}
+
+	report.RejectionRate = rate(rejections, len(jobs))
+	report.ApplicationsPerWeek = fillWeeks(weeks)
+
+	for _, company := range companies {
+		company.RejectionRate = rate(company.Rejections, company.Applications)
+		report.CompanyRejections = append(report.CompanyRejections, *company)
+	}
+	sort.Slice(report.CompanyRejections, func(i, j int) bool {
+		a, b := report.CompanyRejections[i], report.CompanyRejections[j]
+		if a.Rejections != b.Rejections {
+			return a.Rejections > b.Rejections
+		}
+		if a.Applications != b.Applications {
+			return a.Applications > b.Applications
+		}
+		return strings.ToLower(a.Company) < strings.ToLower(b.Company)
+	})
+
+	return report
+}
+
+// reachedStage returns the index of the furthest stage a job has been in
+func (w *workflow) reachedStage(job *models.JobApplication) int {
+	reached := w.stageIndex(job.Status)
+	for _, event := range job.History {
+		if i := w.stageIndex(event.ToStatus); i > reached {
+			reached = i
+		}
+	}
+	return reached
+}
+
+func (w *workflow) stageIndex(status string) int {
+	for i := len(w.stages) - 1; i > 0; i-- {
+		if w.stages[i] == status {
+			return i
+		}
+	}
+	return 0
+}
+
+// daysToFirstResponse measures the time from applying to the first status change
+// that came from the company. The initial status an application was created with
+// is ignored, since imported applications are created long after they were sent.
+func (w *workflow) daysToFirstResponse(job *models.JobApplication) (float64, bool) {
+	for _, event := range job.History {
+		if event.FromStatus == "" || w.nonResponses[event.ToStatus] {
+			continue
+		}
+		days := event.ChangedAt.Sub(job.DateApplied).Hours() / 24
+		if days < 0 {
+			days = 0
+		}
+		return days, true
+	}
+	return 0, false
+}
+
+// weekStart returns the Monday of the week containing t
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// fillWeeks turns week counts into a continuous, ordered series including empty weeks
+func fillWeeks(weeks map[time.Time]int) []WeekCount {
+	if len(weeks) == 0 {
+		return []WeekCount{}
+	}
+
+	var first, last time.Time
+	for week := range weeks {
+		if first.IsZero() || week.Before(first) {
+			first = week
+		}
+		if week.After(last) {
+			last = week
+		}
+	}
+
+	var series []WeekCount
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		series = append(series, WeekCount{WeekStart: week, Count: weeks[week]})
+	}
+	return series
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func rate(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
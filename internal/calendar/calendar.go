@@ -0,0 +1,218 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"hunter-seeker/internal/models"
+)
+
+// productID identifies the application that generated a calendar (PRODID)
+const productID = "-//Hunter-Seeker//Job Applications//EN"
+
+// uidDomain is appended to event UIDs so they stay unique across calendars
+const uidDomain = "hunter-seeker"
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding
+const maxLineOctets = 75
+
+// Event statuses
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is a single VEVENT. All-day events only use the date of Start and End,
+// with End being the day after the last day of the event.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string
+	LastModified time.Time
+}
+
+// Calendar is a named collection of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// InterviewEvent builds the event for an interview. The interview's Job must be set;
+// baseURL is used to link back to the application's edit page.
+func InterviewEvent(interview *models.Interview, baseURL string) Event {
+	job := interview.Job
+
+	var description []string
+	if interview.Interviewers != "" {
+		description = append(description, "Interviewers: "+interview.Interviewers)
+	}
+	description = append(description, "Outcome: "+interview.Outcome)
+	if job.JobURL != "" {
+		description = append(description, "Job posting: "+job.JobURL)
+	}
+	if interview.Feedback != "" {
+		description = append(description, "", interview.Feedback)
+	}
+
+	status := StatusConfirmed
+	if interview.Outcome == models.OutcomeCancelled {
+		status = StatusCancelled
+	}
+
+	return Event{
+		UID:          fmt.Sprintf("interview-%d@%s", interview.ID, uidDomain),
+		Summary:      fmt.Sprintf("%s interview: %s at %s", interview.Round, job.JobTitle, job.Company),
+		Description:  strings.Join(description, "\n"),
+		Location:     interview.Location,
+		URL:          fmt.Sprintf("%s/edit/%d", baseURL, job.ID),
+		Start:        interview.ScheduledAt,
+		End:          interview.EndsAt(),
+		Status:       status,
+		LastModified: interview.UpdatedAt,
+	}
+}
+
+// FollowUpEvent builds the all-day event for a job application's follow-up date.
+// It returns false if the application has no follow-up date.
+func FollowUpEvent(job *models.JobApplication, baseURL string) (Event, bool) {
+	if job.NextActionDate == nil {
+		return Event{}, false
+	}
+
+	description := []string{
+		"Status: " + job.Status,
+		"Applied: " + job.DateApplied.Format("Jan 2, 2006"),
+	}
+	if job.JobURL != "" {
+		description = append(description, "Job posting: "+job.JobURL)
+	}
+
+	return Event{
+		UID:          fmt.Sprintf("follow-up-%d@%s", job.ID, uidDomain),
+		Summary:      fmt.Sprintf("Follow up: %s at %s", job.JobTitle, job.Company),
+		Description:  strings.Join(description, "\n"),
+		URL:          fmt.Sprintf("%s/edit/%d", baseURL, job.ID),
+		Start:        *job.NextActionDate,
+		End:          job.NextActionDate.AddDate(0, 0, 1),
+		AllDay:       true,
+		Status:       StatusConfirmed,
+		LastModified: job.UpdatedAt,
+	}, true
+}
+
+// Write encodes the calendar as an RFC 5545 iCalendar stream.
+// now is used as the DTSTAMP of every event.
+func (c *Calendar) Write(w io.Writer, now time.Time) error {
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + productID)
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + event.UID)
+		lw.line("DTSTAMP:" + formatDateTime(now))
+		if event.AllDay {
+			lw.line("DTSTART;VALUE=DATE:" + formatDate(event.Start))
+			lw.line("DTEND;VALUE=DATE:" + formatDate(event.End))
+		} else {
+			lw.line("DTSTART:" + formatDateTime(event.Start))
+			lw.line("DTEND:" + formatDateTime(event.End))
+		}
+		lw.line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			lw.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		if event.Location != "" {
+			lw.line("LOCATION:" + escapeText(event.Location))
+		}
+		if event.URL != "" {
+			lw.line("URL:" + event.URL)
+		}
+		if event.Status != "" {
+			lw.line("STATUS:" + event.Status)
+		}
+		if !event.LastModified.IsZero() {
+			lw.line("LAST-MODIFIED:" + formatDateTime(event.LastModified))
+		}
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return lw.w.Flush()
+}
+
+// formatDateTime formats t as a UTC DATE-TIME value
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDate formats the calendar date of t as a DATE value.
+// Dates are stored as midnight UTC, so the date is taken in UTC.
+func formatDate(t time.Time) string {
+	return t.UTC().Format("20060102")
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// lineWriter writes CRLF-terminated content lines, folding lines longer
+// than maxLineOctets without splitting UTF-8 sequences
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		lw.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	lw.write(s + "\r\n")
+}
+
+func (lw *lineWriter) write(s string) {
+	if lw.err == nil {
+		_, lw.err = lw.w.WriteString(s)
+	}
+}
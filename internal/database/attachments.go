@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// ErrAttachmentNotFound is returned when an attachment does not exist
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+const attachmentColumns = `id, job_application_id, kind, filename, content_type, size, stored_name, created_at`
+
+// storedExtension matches the file extensions kept on stored attachments
+var storedExtension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// scanAttachment reads an attachment selected with attachmentColumns
+func scanAttachment(row rowScanner) (*models.Attachment, error) {
+	attachment := &models.Attachment{}
+	err := row.Scan(
+		&attachment.ID, &attachment.JobApplicationID, &attachment.Kind, &attachment.Filename,
+		&attachment.ContentType, &attachment.Size, &attachment.StoredName, &attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// CreateAttachment saves an uploaded file to the attachments directory and records it
+// against its job application. The file's size is set from the content written.
+func (db *DB) CreateAttachment(ctx context.Context, attachment *models.Attachment, content io.Reader) error {
+	var exists bool
+	if err := db.conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM job_applications WHERE id = ? AND user_id = ?)`, attachment.JobApplicationID, db.userID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check job application: %w", err)
+	}
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	storedName, err := newStoredName(attachment.Filename)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(db.attachmentsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(db.attachmentsDir, storedName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment file: %w", err)
+	}
+
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		db.removeAttachmentFile(storedName)
+		return fmt.Errorf("failed to write attachment file: %w", err)
+	}
+
+	query := `
+  INSERT INTO attachments (job_application_id, kind, filename, content_type, size, stored_name)
+  VALUES (?, ?, ?, ?, ?, ?)
+  RETURNING id, created_at
+  `
+
+	err = db.conn.QueryRowContext(ctx, query,
+		attachment.JobApplicationID, attachment.Kind, attachment.Filename, attachment.ContentType, size, storedName,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+	if err != nil {
+		db.removeAttachmentFile(storedName)
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	attachment.Size = size
+	attachment.StoredName = storedName
+	return nil
+}
+
+// GetAttachment retrieves an attachment by ID
+func (db *DB) GetAttachment(ctx context.Context, id int) (*models.Attachment, error) {
+	attachment, err := scanAttachment(db.conn.QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM attachments WHERE id = ? AND `+userJobCondition, id, db.userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentsForJob retrieves the attachments of a job application, newest first
+func (db *DB) GetAttachmentsForJob(ctx context.Context, jobID int) ([]*models.Attachment, error) {
+	query := `
+  SELECT ` + attachmentColumns + `
+  FROM attachments
+  WHERE job_application_id = ? AND ` + userJobCondition + `
+  ORDER BY created_at DESC, id DESC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, jobID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*models.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// OpenAttachment opens an attachment's file for reading. The caller must close it.
+func (db *DB) OpenAttachment(attachment *models.Attachment) (io.ReadSeekCloser, error) {
+	file, err := os.Open(filepath.Join(db.attachmentsDir, attachment.StoredName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment file: %w", err)
+	}
+	return file, nil
+}
+
+// DeleteAttachment deletes an attachment and its file
+func (db *DB) DeleteAttachment(ctx context.Context, id int) error {
+	var storedName string
+	err := db.conn.QueryRowContext(ctx, `DELETE FROM attachments WHERE id = ? AND `+userJobCondition+` RETURNING stored_name`, id, db.userID).Scan(&storedName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrAttachmentNotFound
+		}
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return db.removeAttachmentFile(storedName)
+}
+
+// attachmentFiles returns the stored file names of a job application's attachments
+func attachmentFiles(ctx context.Context, tx *txn, jobID int) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT stored_name FROM attachments WHERE job_application_id = ?`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// removeAttachmentFile deletes a stored file. A file that is already gone is not an error.
+func (db *DB) removeAttachmentFile(storedName string) error {
+	err := os.Remove(filepath.Join(db.attachmentsDir, storedName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove attachment file: %w", err)
+	}
+	return nil
+}
+
+// newStoredName returns a random file name for an upload, keeping its extension
+// so the files directory stays browsable. Uploaded names are never used as paths.
+func newStoredName(filename string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate attachment name: %w", err)
+	}
+
+	name := hex.EncodeToString(random)
+	if ext := strings.ToLower(filepath.Ext(filename)); storedExtension.MatchString(ext) {
+		name += ext
+	}
+	return name, nil
+}
@@ -0,0 +1,362 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// Company errors
+var (
+	ErrCompanyNotFound  = errors.New("company not found")
+	ErrCompanyNameTaken = errors.New("another company already uses that name")
+	ErrInvalidAlias     = errors.New("alias must contain letters or numbers")
+)
+
+// companyQuery selects companies with their application and rejection counts. Rejections
+// are applications in a status with the rejection role.
+const companyQuery = `
+  SELECT c.id, c.name, c.size, c.industry, c.website, c.notes, c.created_at, c.updated_at,
+    COUNT(j.id),
+    COALESCE(SUM(CASE WHEN j.status IN (SELECT name FROM statuses WHERE role = 'rejection') THEN 1 ELSE 0 END), 0)
+  FROM companies c
+  LEFT JOIN job_applications j ON j.company_id = c.id
+  `
+
+// scanCompany reads a company selected with companyQuery
+func scanCompany(row rowScanner) (*models.Company, error) {
+	company := &models.Company{}
+	err := row.Scan(
+		&company.ID, &company.Name, &company.Size, &company.Industry, &company.Website,
+		&company.Notes, &company.CreatedAt, &company.UpdatedAt,
+		&company.ApplicationCount, &company.RejectionCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+// queryRower is satisfied by both *conn and *txn
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// findCompanyID returns the user's company a normalized name resolves to, by name or alias.
+// It returns 0 if no company matches.
+func findCompanyID(ctx context.Context, q queryRower, userID int, normalized string) (int, error) {
+	query := `
+  SELECT id FROM companies WHERE user_id = ? AND normalized_name = ?
+  UNION ALL
+  SELECT company_id FROM company_aliases WHERE user_id = ? AND normalized_alias = ?
+  LIMIT 1
+  `
+
+	var id int
+	err := q.QueryRowContext(ctx, query, userID, normalized, userID, normalized).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up company: %w", err)
+	}
+	return id, nil
+}
+
+// resolveCompanyID finds the user's company a job's company name refers to, creating
+// it if this is the first application there. Blank names are not linked.
+func resolveCompanyID(ctx context.Context, tx *txn, userID int, name string) (sql.NullInt64, error) {
+	normalized := models.NormalizeCompanyName(name)
+	if normalized == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	id, err := findCompanyID(ctx, tx, userID, normalized)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	if id == 0 {
+		err := tx.QueryRowContext(ctx, `INSERT INTO companies (user_id, name, normalized_name) VALUES (?, ?, ?) RETURNING id`, userID, strings.TrimSpace(name), normalized).Scan(&id)
+		if err != nil {
+			return sql.NullInt64{}, fmt.Errorf("failed to create company: %w", err)
+		}
+	}
+
+	return sql.NullInt64{Int64: int64(id), Valid: true}, nil
+}
+
+// GetAllCompanies retrieves every company with its application counts, ordered by name
+func (db *DB) GetAllCompanies(ctx context.Context) ([]*models.Company, error) {
+	rows, err := db.conn.QueryContext(ctx, companyQuery+`
+  WHERE c.user_id = ?
+  GROUP BY c.id
+  ORDER BY LOWER(c.name), c.id
+  `, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query companies: %w", err)
+	}
+	defer rows.Close()
+
+	var companies []*models.Company
+	for rows.Next() {
+		company, err := scanCompany(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+	}
+
+	return companies, rows.Err()
+}
+
+// GetCompany retrieves a company with its aliases and applications
+func (db *DB) GetCompany(ctx context.Context, id int) (*models.Company, error) {
+	company, err := scanCompany(db.conn.QueryRowContext(ctx, companyQuery+`
+  WHERE c.id = ? AND c.user_id = ?
+  GROUP BY c.id
+  `, id, db.userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	company.Aliases, err = db.getCompanyAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.QueryContext(ctx, `
+  SELECT `+jobColumns("")+`
+  FROM job_applications
+  WHERE company_id = ? AND user_id = ?
+  ORDER BY date_applied DESC, created_at DESC
+  `, id, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company applications: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		company.Applications = append(company.Applications, job)
+	}
+
+	return company, rows.Err()
+}
+
+// FindCompanyByName returns the company a name would be linked to, without creating one
+func (db *DB) FindCompanyByName(ctx context.Context, name string) (*models.Company, error) {
+	normalized := models.NormalizeCompanyName(name)
+	if normalized == "" {
+		return nil, ErrCompanyNotFound
+	}
+
+	id, err := findCompanyID(ctx, db.conn, db.userID, normalized)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		return nil, ErrCompanyNotFound
+	}
+
+	return db.GetCompany(ctx, id)
+}
+
+func (db *DB) getCompanyAliases(ctx context.Context, companyID int) ([]models.CompanyAlias, error) {
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, company_id, alias FROM company_aliases WHERE company_id = ? ORDER BY LOWER(alias)`, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []models.CompanyAlias
+	for rows.Next() {
+		var alias models.CompanyAlias
+		if err := rows.Scan(&alias.ID, &alias.CompanyID, &alias.Alias); err != nil {
+			return nil, fmt.Errorf("failed to scan company alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// UpdateCompany updates a company's name and details. When the name changes to
+// a different normalized form the old name is kept as an alias, so applications
+// using it stay linked.
+func (db *DB) UpdateCompany(ctx context.Context, company *models.Company) error {
+	normalized := models.NormalizeCompanyName(company.Name)
+	if normalized == "" {
+		return ErrInvalidAlias
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldName, oldNormalized string
+	err = tx.QueryRowContext(ctx, `SELECT name, normalized_name FROM companies WHERE id = ? AND user_id = ?`, company.ID, db.userID).Scan(&oldName, &oldNormalized)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCompanyNotFound
+		}
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+
+	if normalized != oldNormalized {
+		existing, err := findCompanyID(ctx, tx, db.userID, normalized)
+		if err != nil {
+			return err
+		}
+		if existing != 0 && existing != company.ID {
+			return ErrCompanyNameTaken
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM company_aliases WHERE user_id = ? AND normalized_alias = ?`, db.userID, normalized); err != nil {
+			return fmt.Errorf("failed to remove company alias: %w", err)
+		}
+		if err := insertCompanyAlias(ctx, tx, db.userID, company.ID, oldName, oldNormalized); err != nil {
+			return err
+		}
+	}
+
+	query := `
+  UPDATE companies
+  SET name = ?, normalized_name = ?, size = ?, industry = ?, website = ?, notes = ?
+  WHERE id = ?
+  `
+	_, err = tx.ExecContext(ctx, query, strings.TrimSpace(company.Name), normalized, company.Size, company.Industry, company.Website, company.Notes, company.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit company update: %w", err)
+	}
+
+	return nil
+}
+
+// AddCompanyAlias records another name for a company. If the alias already
+// resolves to a different company, that company is merged into this one:
+// its applications and aliases move here and blank details are filled from it.
+func (db *DB) AddCompanyAlias(ctx context.Context, companyID int, alias string) error {
+	normalized := models.NormalizeCompanyName(alias)
+	if normalized == "" {
+		return ErrInvalidAlias
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var ownNormalized string
+	err = tx.QueryRowContext(ctx, `SELECT normalized_name FROM companies WHERE id = ? AND user_id = ?`, companyID, db.userID).Scan(&ownNormalized)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCompanyNotFound
+		}
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+
+	if normalized == ownNormalized {
+		return nil
+	}
+
+	otherID, err := findCompanyID(ctx, tx, db.userID, normalized)
+	if err != nil {
+		return err
+	}
+	if otherID != 0 && otherID != companyID {
+		if err := mergeCompany(ctx, tx, db.userID, companyID, otherID); err != nil {
+			return err
+		}
+	}
+
+	if err := insertCompanyAlias(ctx, tx, db.userID, companyID, alias, normalized); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit company alias: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveCompanyAlias deletes one of a company's aliases. Applications already
+// linked keep their company until their company name is edited.
+func (db *DB) RemoveCompanyAlias(ctx context.Context, companyID, aliasID int) error {
+	result, err := db.conn.ExecContext(ctx, `DELETE FROM company_aliases WHERE id = ? AND company_id = ? AND user_id = ?`, aliasID, companyID, db.userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete company alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrCompanyNotFound
+	}
+
+	return nil
+}
+
+func insertCompanyAlias(ctx context.Context, tx *txn, userID, companyID int, alias, normalized string) error {
+	query := `
+  INSERT INTO company_aliases (user_id, company_id, alias, normalized_alias)
+  VALUES (?, ?, ?, ?)
+  ON CONFLICT (user_id, normalized_alias) DO UPDATE SET company_id = excluded.company_id, alias = excluded.alias
+  `
+	if _, err := tx.ExecContext(ctx, query, userID, companyID, strings.TrimSpace(alias), normalized); err != nil {
+		return fmt.Errorf("failed to add company alias: %w", err)
+	}
+	return nil
+}
+
+// mergeCompany moves everything belonging to the user's company from into company into, then deletes from
+func mergeCompany(ctx context.Context, tx *txn, userID, into, from int) error {
+	var name, normalized string
+	err := tx.QueryRowContext(ctx, `SELECT name, normalized_name FROM companies WHERE id = ?`, from).Scan(&name, &normalized)
+	if err != nil {
+		return fmt.Errorf("failed to get merged company: %w", err)
+	}
+
+	statements := []string{
+		`UPDATE job_applications SET company_id = ? WHERE company_id = ?`,
+		`UPDATE company_aliases SET company_id = ? WHERE company_id = ?`,
+		`UPDATE companies SET
+    size = CASE WHEN size = '' THEN (SELECT size FROM companies WHERE id = ?2) ELSE size END,
+    industry = CASE WHEN industry = '' THEN (SELECT industry FROM companies WHERE id = ?2) ELSE industry END,
+    website = CASE WHEN website = '' THEN (SELECT website FROM companies WHERE id = ?2) ELSE website END,
+    notes = CASE WHEN notes = '' THEN (SELECT notes FROM companies WHERE id = ?2) ELSE notes END
+  WHERE id = ?1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, into, from); err != nil {
+			return fmt.Errorf("failed to merge company: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM companies WHERE id = ?`, from); err != nil {
+		return fmt.Errorf("failed to delete merged company: %w", err)
+	}
+
+	return insertCompanyAlias(ctx, tx, userID, into, name, normalized)
+}
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"hunter-seeker/internal/models"
+)
+
+// ErrContactNotFound is returned when a contact does not exist
+var ErrContactNotFound = errors.New("contact not found")
+
+const contactColumns = `id, name, role, email, phone, linkedin_url, notes, created_at, updated_at`
+
+// scanContacts reads contacts selected with contactColumns
+func scanContacts(rows *sql.Rows) ([]*models.Contact, error) {
+	var contacts []*models.Contact
+	for rows.Next() {
+		contact := &models.Contact{}
+		err := rows.Scan(
+			&contact.ID, &contact.Name, &contact.Role, &contact.Email, &contact.Phone,
+			&contact.LinkedInURL, &contact.Notes, &contact.CreatedAt, &contact.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, rows.Err()
+}
+
+// CreateContact inserts a new contact
+func (db *DB) CreateContact(ctx context.Context, contact *models.Contact) error {
+	query := `
+  INSERT INTO contacts (user_id, name, role, email, phone, linkedin_url, notes)
+  VALUES (?, ?, ?, ?, ?, ?, ?)
+  RETURNING id
+  `
+
+	err := db.conn.QueryRowContext(ctx, query, db.userID, contact.Name, contact.Role, contact.Email, contact.Phone, contact.LinkedInURL, contact.Notes).Scan(&contact.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+
+	return nil
+}
+
+// GetContact retrieves a contact by ID along with the applications it is linked to
+func (db *DB) GetContact(ctx context.Context, id int) (*models.Contact, error) {
+	contact := &models.Contact{}
+	err := db.conn.QueryRowContext(ctx, `SELECT `+contactColumns+` FROM contacts WHERE id = ? AND user_id = ?`, id, db.userID).Scan(
+		&contact.ID, &contact.Name, &contact.Role, &contact.Email, &contact.Phone,
+		&contact.LinkedInURL, &contact.Notes, &contact.CreatedAt, &contact.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	contact.Applications, err = db.GetJobApplicationsForContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+// GetAllContacts retrieves all contacts, ordered by name
+func (db *DB) GetAllContacts(ctx context.Context) ([]*models.Contact, error) {
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+contactColumns+` FROM contacts WHERE user_id = ? ORDER BY LOWER(name), id`, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contacts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanContacts(rows)
+}
+
+// UpdateContact updates an existing contact
+func (db *DB) UpdateContact(ctx context.Context, contact *models.Contact) error {
+	query := `
+  UPDATE contacts
+  SET name = ?, role = ?, email = ?, phone = ?, linkedin_url = ?, notes = ?
+  WHERE id = ? AND user_id = ?
+  `
+
+	result, err := db.conn.ExecContext(ctx, query, contact.Name, contact.Role, contact.Email, contact.Phone, contact.LinkedInURL, contact.Notes, contact.ID, db.userID)
+	if err != nil {
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrContactNotFound
+	}
+
+	return nil
+}
+
+// DeleteContact deletes a contact and its links to applications
+func (db *DB) DeleteContact(ctx context.Context, id int) error {
+	result, err := db.conn.ExecContext(ctx, `DELETE FROM contacts WHERE id = ? AND user_id = ?`, id, db.userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrContactNotFound
+	}
+
+	return nil
+}
+
+// GetContactsForJob retrieves the contacts linked to a job application, ordered by name
+func (db *DB) GetContactsForJob(ctx context.Context, jobID int) ([]*models.Contact, error) {
+	query := `
+  SELECT c.id, c.name, c.role, c.email, c.phone, c.linkedin_url, c.notes, c.created_at, c.updated_at
+  FROM contacts c
+  JOIN job_application_contacts jc ON jc.contact_id = c.id
+  WHERE jc.job_application_id = ? AND c.user_id = ?
+  ORDER BY LOWER(c.name), c.id
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, jobID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contacts for job application: %w", err)
+	}
+	defer rows.Close()
+
+	return scanContacts(rows)
+}
+
+// GetJobApplicationsForContact retrieves the applications a contact is linked to, newest first
+func (db *DB) GetJobApplicationsForContact(ctx context.Context, contactID int) ([]*models.JobApplication, error) {
+	query := `
+  SELECT ` + jobColumns("j") + `
+  FROM job_applications j
+  JOIN job_application_contacts jc ON jc.job_application_id = j.id
+  WHERE jc.contact_id = ? AND j.user_id = ?
+  ORDER BY j.date_applied DESC, j.created_at DESC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, contactID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job applications for contact: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.JobApplication
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// LinkContact links a contact to a job application. Linking twice is not an error.
+func (db *DB) LinkContact(ctx context.Context, jobID, contactID int) error {
+	if err := db.checkJobAndContact(ctx, jobID, contactID); err != nil {
+		return err
+	}
+
+	query := `
+  INSERT INTO job_application_contacts (job_application_id, contact_id)
+  VALUES (?, ?)
+  ON CONFLICT (job_application_id, contact_id) DO NOTHING
+  `
+
+	if _, err := db.conn.ExecContext(ctx, query, jobID, contactID); err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+
+	return nil
+}
+
+// UnlinkContact removes the link between a contact and a job application
+func (db *DB) UnlinkContact(ctx context.Context, jobID, contactID int) error {
+	if err := db.checkJobAndContact(ctx, jobID, contactID); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM job_application_contacts WHERE job_application_id = ? AND contact_id = ?`
+	if _, err := db.conn.ExecContext(ctx, query, jobID, contactID); err != nil {
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+
+	return nil
+}
+
+// checkJobAndContact returns ErrJobNotFound or ErrContactNotFound if either row is missing
+func (db *DB) checkJobAndContact(ctx context.Context, jobID, contactID int) error {
+	var jobExists, contactExists bool
+	query := `
+  SELECT
+    EXISTS (SELECT 1 FROM job_applications WHERE id = ? AND user_id = ?),
+    EXISTS (SELECT 1 FROM contacts WHERE id = ? AND user_id = ?)
+  `
+
+	if err := db.conn.QueryRowContext(ctx, query, jobID, db.userID, contactID, db.userID).Scan(&jobExists, &contactExists); err != nil {
+		return fmt.Errorf("failed to check job application and contact: %w", err)
+	}
+	if !jobExists {
+		return ErrJobNotFound
+	}
+	if !contactExists {
+		return ErrContactNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// dialect describes what differs between the SQL databases a DB can use. Queries in
+// this package are written for SQLite, with ? placeholders, and rewritten for others.
+type dialect struct {
+	// driver is the database/sql driver name
+	driver string
+	// numberedPlaceholders means placeholders are written $1, $2, ... instead of ?
+	numberedPlaceholders bool
+	// migrations lists the dialect's schema changes; versions match across dialects
+	migrations []migration
+	// migrationsTable creates the schema_migrations table if it does not exist
+	migrationsTable string
+	// lockMigrations and unlockMigrations, if set, keep servers sharing a database
+	// from migrating it at the same time
+	lockMigrations   string
+	unlockMigrations string
+	// search runs a full-text search; see SearchJobApplications
+	search func(ctx context.Context, db *DB, query SearchQuery, status string) ([]models.SearchResult, error)
+}
+
+var sqliteDialect = &dialect{
+	driver:     "sqlite",
+	migrations: migrations,
+	migrationsTable: `
+  CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    description TEXT NOT NULL,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  )
+  `,
+	search: searchSQLite,
+}
+
+var postgresDialect = &dialect{
+	driver:               "pgx",
+	numberedPlaceholders: true,
+	migrations:           postgresMigrations,
+	migrationsTable: `
+  CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    description TEXT NOT NULL,
+    applied_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  )
+  `,
+	// An arbitrary key for a session-level advisory lock, held while migrating
+	lockMigrations:   `SELECT pg_advisory_lock(7262021)`,
+	unlockMigrations: `SELECT pg_advisory_unlock(7262021)`,
+	search:           searchPostgres,
+}
+
+// rebind rewrites a query's placeholders for the dialect. Plain ? placeholders are
+// numbered in order and ?N keeps its number; placeholders inside quotes are left alone.
+func (d *dialect) rebind(query string) string {
+	if !d.numberedPlaceholders || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	next := 1
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			b.WriteByte('$')
+			if i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+				continue
+			}
+			b.WriteString(strconv.Itoa(next))
+			next++
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// conn is the connection pool, rewriting queries for its dialect. Only the Context
+// methods of database/sql are offered, so every query can be cancelled.
+type conn struct {
+	pool    *sql.DB
+	dialect *dialect
+}
+
+// ExecContext runs a statement, rewritten for the dialect
+func (c *conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.pool.ExecContext(ctx, c.dialect.rebind(query), args...)
+}
+
+// QueryContext runs a query, rewritten for the dialect
+func (c *conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.pool.QueryContext(ctx, c.dialect.rebind(query), args...)
+}
+
+// QueryRowContext runs a query returning at most one row, rewritten for the dialect
+func (c *conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.pool.QueryRowContext(ctx, c.dialect.rebind(query), args...)
+}
+
+// BeginTx starts a transaction whose queries are rewritten for the dialect. The
+// transaction is rolled back if ctx is done before it commits.
+func (c *conn) BeginTx(ctx context.Context) (*txn, error) {
+	tx, err := c.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &txn{tx: tx, dialect: c.dialect}, nil
+}
+
+// txn is a transaction, rewriting queries for its dialect
+type txn struct {
+	tx      *sql.Tx
+	dialect *dialect
+}
+
+// ExecContext runs a statement, rewritten for the dialect
+func (t *txn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, t.dialect.rebind(query), args...)
+}
+
+// QueryContext runs a query, rewritten for the dialect
+func (t *txn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, t.dialect.rebind(query), args...)
+}
+
+// QueryRowContext runs a query returning at most one row, rewritten for the dialect
+func (t *txn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(ctx, t.dialect.rebind(query), args...)
+}
+
+// Commit commits the transaction
+func (t *txn) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback aborts the transaction, if it has not been committed
+func (t *txn) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hunter-seeker/internal/followup"
+	"hunter-seeker/internal/models"
+)
+
+// ErrFollowUpRuleNotFound is returned when a follow-up rule does not exist
+var ErrFollowUpRuleNotFound = errors.New("follow-up rule not found")
+
+// GetFollowUpRules retrieves all follow-up rules, ordered by status. Rules are shared by every user.
+func (db *DB) GetFollowUpRules(ctx context.Context) ([]*models.FollowUpRule, error) {
+	query := `
+  SELECT id, status, follow_up_days, no_response_days, created_at, updated_at
+  FROM follow_up_rules
+  ORDER BY LOWER(status)
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query follow-up rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*models.FollowUpRule
+	for rows.Next() {
+		rule := &models.FollowUpRule{}
+		err := rows.Scan(&rule.ID, &rule.Status, &rule.FollowUpDays, &rule.NoResponseDays, &rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// SaveFollowUpRule creates the rule for a status, or replaces the existing one
+func (db *DB) SaveFollowUpRule(ctx context.Context, rule *models.FollowUpRule) error {
+	query := `
+  INSERT INTO follow_up_rules (status, follow_up_days, no_response_days)
+  VALUES (?, ?, ?)
+  ON CONFLICT (status) DO UPDATE SET
+    follow_up_days = excluded.follow_up_days,
+    no_response_days = excluded.no_response_days
+  RETURNING id
+  `
+
+	if err := db.conn.QueryRowContext(ctx, query, rule.Status, rule.FollowUpDays, rule.NoResponseDays).Scan(&rule.ID); err != nil {
+		return fmt.Errorf("failed to save follow-up rule: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFollowUpRule deletes a follow-up rule
+func (db *DB) DeleteFollowUpRule(ctx context.Context, id int) error {
+	result, err := db.conn.ExecContext(ctx, `DELETE FROM follow_up_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete follow-up rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrFollowUpRuleNotFound
+	}
+
+	return nil
+}
+
+// SetNextActionDate sets or, with nil, clears a job application's follow-up date
+func (db *DB) SetNextActionDate(ctx context.Context, id int, date *time.Time) error {
+	result, err := db.conn.ExecContext(ctx, `UPDATE job_applications SET next_action_date = ? WHERE id = ? AND user_id = ?`, nullableTime(date), id, db.userID)
+	if err != nil {
+		return fmt.Errorf("failed to set follow-up date: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// GetApplicationsNeedingAttention returns the applications whose follow-up is due as of now
+func (db *DB) GetApplicationsNeedingAttention(ctx context.Context, now time.Time) ([]followup.Item, error) {
+	rules, err := db.GetFollowUpRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := db.GetAllJobApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return followup.NeedsAttention(jobs, rules, now), nil
+}
+
+// MoveSilentApplications moves applications that have been silent for longer than
+// their rule's no-response period to the status with the no-response role, recording
+// the status change. The rules are shared, so every user's applications are checked.
+// Applications whose status does not allow the move are left alone, and nothing moves
+// if no status has the role. It returns the number of applications moved.
+func (db *DB) MoveSilentApplications(ctx context.Context, now time.Time) (int, error) {
+	noResponse, err := noResponseStatus(ctx, db.conn)
+	if err != nil || noResponse == "" {
+		return 0, err
+	}
+
+	users, err := db.GetUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, user := range users {
+		count, err := db.forUser(user.ID).moveSilentApplications(ctx, noResponse, now)
+		if err != nil {
+			return moved, err
+		}
+		moved += count
+	}
+
+	return moved, nil
+}
+
+// moveSilentApplications moves the user's silent applications to the noResponse status
+func (db *DB) moveSilentApplications(ctx context.Context, noResponse string, now time.Time) (int, error) {
+	rules, err := db.GetFollowUpRules(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	jobs, err := db.GetAllJobApplications(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	silent := followup.Silent(jobs, rules, noResponse, now)
+	if len(silent) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	moved := 0
+	for _, job := range silent {
+		if err := checkTransition(ctx, tx, job.Status, noResponse); err != nil {
+			if errors.Is(err, ErrTransitionNotAllowed) {
+				continue
+			}
+			return 0, err
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE job_applications SET status = ? WHERE id = ?`, noResponse, job.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update status: %w", err)
+		}
+		if err := recordStatusEvent(ctx, tx, job.ID, job.Status, noResponse); err != nil {
+			return 0, err
+		}
+		moved++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit status changes: %w", err)
+	}
+
+	return moved, nil
+}
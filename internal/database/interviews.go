@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// ErrInterviewNotFound is returned when an interview does not exist
+var ErrInterviewNotFound = errors.New("interview not found")
+
+const interviewColumns = `id, job_application_id, round, scheduled_at, duration_minutes, location, interviewers, outcome, feedback, created_at, updated_at`
+
+// scanInterview reads an interview selected with interviewColumns, followed by any extra columns.
+// Times are stored in UTC and returned in the server's local time zone.
+func scanInterview(row rowScanner, extra ...interface{}) (*models.Interview, error) {
+	interview := &models.Interview{}
+	dest := []interface{}{
+		&interview.ID, &interview.JobApplicationID, &interview.Round, &interview.ScheduledAt,
+		&interview.DurationMinutes, &interview.Location, &interview.Interviewers, &interview.Outcome,
+		&interview.Feedback, &interview.CreatedAt, &interview.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	interview.ScheduledAt = interview.ScheduledAt.Local()
+	return interview, nil
+}
+
+// CreateInterview adds an interview round to a job application
+func (db *DB) CreateInterview(ctx context.Context, interview *models.Interview) error {
+	query := `
+  INSERT INTO interviews (job_application_id, round, scheduled_at, duration_minutes, location, interviewers, outcome, feedback)
+  VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+  RETURNING id
+  `
+
+	var exists bool
+	if err := db.conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM job_applications WHERE id = ? AND user_id = ?)`, interview.JobApplicationID, db.userID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check job application: %w", err)
+	}
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	err := db.conn.QueryRowContext(ctx, query,
+		interview.JobApplicationID, interview.Round, interview.ScheduledAt.UTC(), interview.DurationMinutes,
+		interview.Location, interview.Interviewers, interview.Outcome, interview.Feedback,
+	).Scan(&interview.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create interview: %w", err)
+	}
+
+	return nil
+}
+
+// GetInterview retrieves an interview by ID
+func (db *DB) GetInterview(ctx context.Context, id int) (*models.Interview, error) {
+	interview, err := scanInterview(db.conn.QueryRowContext(ctx, `SELECT `+interviewColumns+` FROM interviews WHERE id = ? AND `+userJobCondition, id, db.userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+
+	return interview, nil
+}
+
+// UpdateInterview updates an existing interview. The application it belongs to cannot change.
+func (db *DB) UpdateInterview(ctx context.Context, interview *models.Interview) error {
+	query := `
+  UPDATE interviews
+  SET round = ?, scheduled_at = ?, duration_minutes = ?, location = ?, interviewers = ?, outcome = ?, feedback = ?
+  WHERE id = ? AND ` + userJobCondition
+
+	result, err := db.conn.ExecContext(ctx, query,
+		interview.Round, interview.ScheduledAt.UTC(), interview.DurationMinutes, interview.Location,
+		interview.Interviewers, interview.Outcome, interview.Feedback, interview.ID, db.userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrInterviewNotFound
+	}
+
+	return nil
+}
+
+// DeleteInterview deletes an interview by ID
+func (db *DB) DeleteInterview(ctx context.Context, id int) error {
+	result, err := db.conn.ExecContext(ctx, `DELETE FROM interviews WHERE id = ? AND `+userJobCondition, id, db.userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete interview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrInterviewNotFound
+	}
+
+	return nil
+}
+
+// GetInterviewsForJob retrieves a job application's interviews in the order they happen
+func (db *DB) GetInterviewsForJob(ctx context.Context, jobID int) ([]*models.Interview, error) {
+	query := `
+  SELECT ` + interviewColumns + `
+  FROM interviews
+  WHERE job_application_id = ? AND ` + userJobCondition + `
+  ORDER BY scheduled_at ASC, id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, jobID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interviews: %w", err)
+	}
+	defer rows.Close()
+
+	var interviews []*models.Interview
+	for rows.Next() {
+		interview, err := scanInterview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interview: %w", err)
+		}
+		interviews = append(interviews, interview)
+	}
+
+	return interviews, rows.Err()
+}
+
+// GetUpcomingInterviews retrieves scheduled interviews that have not finished by now,
+// soonest first, each with its job application. A limit of zero returns them all.
+func (db *DB) GetUpcomingInterviews(ctx context.Context, now time.Time, limit int) ([]*models.Interview, error) {
+	// Interviews still in progress count as upcoming, so look back a day and
+	// drop the ones that have ended once their duration is known
+	interviews, err := db.queryInterviewsWithJobs(ctx, `i.outcome = ? AND i.scheduled_at >= ?`,
+		models.OutcomeScheduled, now.UTC().Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := interviews[:0]
+	for _, interview := range interviews {
+		if interview.EndsAt().Before(now) {
+			continue
+		}
+		upcoming = append(upcoming, interview)
+		if limit > 0 && len(upcoming) == limit {
+			break
+		}
+	}
+
+	return upcoming, nil
+}
+
+// GetAllInterviews retrieves every interview, oldest first, each with its job application
+func (db *DB) GetAllInterviews(ctx context.Context) ([]*models.Interview, error) {
+	return db.queryInterviewsWithJobs(ctx, "")
+}
+
+// queryInterviewsWithJobs lists the user's interviews joined with their job applications,
+// filtered by an optional condition over the aliases i and j
+func (db *DB) queryInterviewsWithJobs(ctx context.Context, condition string, args ...interface{}) ([]*models.Interview, error) {
+	where := "WHERE j.user_id = ?"
+	if condition != "" {
+		where += " AND " + condition
+	}
+	args = append([]interface{}{db.userID}, args...)
+
+	query := `
+  SELECT ` + jobColumns("j") + `,
+    i.id, i.job_application_id, i.round, i.scheduled_at, i.duration_minutes, i.location,
+    i.interviewers, i.outcome, i.feedback, i.created_at, i.updated_at
+  FROM interviews i
+  JOIN job_applications j ON j.id = i.job_application_id
+  ` + where + `
+  ORDER BY i.scheduled_at ASC, i.id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interviews: %w", err)
+	}
+	defer rows.Close()
+
+	var interviews []*models.Interview
+	for rows.Next() {
+		interview := &models.Interview{}
+		job, err := scanJob(rows,
+			&interview.ID, &interview.JobApplicationID, &interview.Round, &interview.ScheduledAt,
+			&interview.DurationMinutes, &interview.Location, &interview.Interviewers, &interview.Outcome,
+			&interview.Feedback, &interview.CreatedAt, &interview.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interview: %w", err)
+		}
+
+		interview.ScheduledAt = interview.ScheduledAt.Local()
+		interview.Job = job
+		interviews = append(interviews, interview)
+	}
+
+	return interviews, rows.Err()
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// Sort keys accepted by ListJobApplications
+const (
+	SortDate    = "date"
+	SortCompany = "company"
+	SortStatus  = "status"
+	SortUpdated = "updated"
+	SortSalary  = "salary"
+)
+
+// sortColumns maps sort keys to the SQL expression they order by.
+// Only these expressions are ever interpolated into the query.
+var sortColumns = map[string]string{
+	SortDate:    "date_applied",
+	SortCompany: "LOWER(company)",
+	SortStatus:  "status",
+	SortUpdated: "updated_at",
+	// Salary ranges sort by their top end, or their bottom end if that is all we know
+	SortSalary: "COALESCE(salary_max, salary_min)",
+}
+
+// ListOptions controls filtering, sorting and pagination of job application lists
+type ListOptions struct {
+	// Status limits results to a single status when set
+	Status string
+	// Tags limits results to applications with any of these tags,
+	// or with all of them when MatchAllTags is set
+	Tags         []string
+	MatchAllTags bool
+	// WorkMode limits results to one work mode when set
+	WorkMode string
+	// Location limits results to locations containing this text, ignoring case
+	Location string
+	// MinSalary limits results to salary ranges reaching at least this amount
+	MinSalary *int
+	// Sort is one of the Sort* keys; unknown keys sort by date
+	Sort       string
+	Descending bool
+	// Limit is the page size; zero returns every matching row
+	Limit  int
+	Offset int
+}
+
+// ListJobApplications returns one page of job applications along with the
+// total number of applications matching the filter
+func (db *DB) ListJobApplications(ctx context.Context, opts ListOptions) ([]*models.JobApplication, int, error) {
+	conditions := []string{"user_id = ?"}
+	args := []interface{}{db.userID}
+	if opts.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, opts.Status)
+	}
+	if tags := models.NormalizeTags(opts.Tags); len(tags) > 0 {
+		condition, tagArgs := tagFilter(db.userID, tags, opts.MatchAllTags)
+		conditions = append(conditions, condition)
+		args = append(args, tagArgs...)
+	}
+
+	if opts.WorkMode != "" {
+		conditions = append(conditions, "work_mode = ?")
+		args = append(args, opts.WorkMode)
+	}
+	if opts.Location != "" {
+		conditions = append(conditions, `LOWER(location) LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(strings.ToLower(opts.Location))+"%")
+	}
+	if opts.MinSalary != nil {
+		conditions = append(conditions, "COALESCE(salary_max, salary_min) >= ?")
+		args = append(args, *opts.MinSalary)
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM job_applications "+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count job applications: %w", err)
+	}
+
+	column, ok := sortColumns[opts.Sort]
+	if !ok {
+		column = sortColumns[SortDate]
+	}
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+
+	// Unknown values such as missing salaries come last in either direction;
+	// created_at and id break ties so pages stay stable between requests
+	query := fmt.Sprintf(`
+  SELECT `+jobColumns("")+`
+  FROM job_applications
+  %s
+  ORDER BY %s %s NULLS LAST, created_at %s, id %s
+  `, where, column, direction, direction, direction)
+
+	if opts.Limit > 0 {
+		query += "LIMIT ? OFFSET ?"
+		args = append(args, opts.Limit, opts.Offset)
+	}
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query job applications: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.JobApplication
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read job applications: %w", err)
+	}
+
+	if err := db.attachTags(ctx, jobs); err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term, for use with ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes s match literally in a LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
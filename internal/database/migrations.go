@@ -0,0 +1,784 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer
+// version of the application than the one currently running.
+var ErrSchemaTooNew = errors.New("database schema is newer than this application supports")
+
+// migration is a single, forward-only schema change
+type migration struct {
+	version     int
+	description string
+	up          string
+	// apply runs after up for data changes that need Go code
+	apply func(ctx context.Context, tx *txn) error
+	// rebuildsTables runs the migration with foreign keys off, as SQLite requires when
+	// recreating a table other tables refer to, so dropping the old table does not
+	// cascade. The references are checked before the migration commits.
+	rebuildsTables bool
+}
+
+// migrations lists every schema change to SQLite databases in the order it is applied.
+// Append new entries to the end, and to postgresMigrations with the same version;
+// never edit or reorder a migration that has shipped.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create job_applications table",
+		// IF NOT EXISTS lets databases created before migrations existed adopt this version
+		up: `
+  CREATE TABLE IF NOT EXISTS job_applications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date_applied DATE NOT NULL,
+    job_title TEXT NOT NULL,
+    company TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Applied',
+    job_url TEXT,
+    notes TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER IF NOT EXISTS update_job_applications_updated_at
+  AFTER UPDATE ON job_applications
+  BEGIN
+    UPDATE job_applications SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+  `,
+	},
+	{
+		version:     2,
+		description: "create status_events table",
+		// Existing applications get a single event for their current status,
+		// dated when they were last updated since earlier changes were never recorded
+		up: `
+  CREATE TABLE status_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL,
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_status_events_job_application_id ON status_events(job_application_id, changed_at);
+
+  INSERT INTO status_events (job_application_id, from_status, to_status, changed_at)
+  SELECT id, '', status, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP) FROM job_applications;
+  `,
+	},
+	{
+		version:     3,
+		description: "create job_applications_fts full-text index",
+		// External-content FTS5 table kept in sync by triggers; the update trigger only
+		// fires for indexed columns so the updated_at trigger does not reindex every row
+		up: `
+  CREATE VIRTUAL TABLE job_applications_fts USING fts5(
+    job_title, company, notes, job_url,
+    content='job_applications', content_rowid='id',
+    tokenize='unicode61 remove_diacritics 2'
+  );
+
+  CREATE TRIGGER job_applications_fts_insert AFTER INSERT ON job_applications
+  BEGIN
+    INSERT INTO job_applications_fts (rowid, job_title, company, notes, job_url)
+    VALUES (NEW.id, NEW.job_title, NEW.company, NEW.notes, NEW.job_url);
+  END;
+
+  CREATE TRIGGER job_applications_fts_delete AFTER DELETE ON job_applications
+  BEGIN
+    INSERT INTO job_applications_fts (job_applications_fts, rowid, job_title, company, notes, job_url)
+    VALUES ('delete', OLD.id, OLD.job_title, OLD.company, OLD.notes, OLD.job_url);
+  END;
+
+  CREATE TRIGGER job_applications_fts_update AFTER UPDATE OF job_title, company, notes, job_url ON job_applications
+  BEGIN
+    INSERT INTO job_applications_fts (job_applications_fts, rowid, job_title, company, notes, job_url)
+    VALUES ('delete', OLD.id, OLD.job_title, OLD.company, OLD.notes, OLD.job_url);
+    INSERT INTO job_applications_fts (rowid, job_title, company, notes, job_url)
+    VALUES (NEW.id, NEW.job_title, NEW.company, NEW.notes, NEW.job_url);
+  END;
+
+  INSERT INTO job_applications_fts (job_applications_fts) VALUES ('rebuild');
+  `,
+	},
+	{
+		version:     4,
+		description: "index job_applications sort columns",
+		up: `
+  CREATE INDEX idx_job_applications_date_applied ON job_applications(date_applied);
+  CREATE INDEX idx_job_applications_company ON job_applications(LOWER(company));
+  CREATE INDEX idx_job_applications_status ON job_applications(status);
+  CREATE INDEX idx_job_applications_updated_at ON job_applications(updated_at);
+  `,
+	},
+	{
+		version:     5,
+		description: "create contacts and job_application_contacts tables",
+		up: `
+  CREATE TABLE contacts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    linkedin_url TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_contacts_name ON contacts(LOWER(name));
+
+  CREATE TRIGGER update_contacts_updated_at
+  AFTER UPDATE ON contacts
+  BEGIN
+    UPDATE contacts SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  CREATE TABLE job_application_contacts (
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    contact_id INTEGER NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_application_id, contact_id)
+  );
+
+  CREATE INDEX idx_job_application_contacts_contact_id ON job_application_contacts(contact_id);
+  `,
+	},
+	{
+		version:     6,
+		description: "create companies and link job applications to them",
+		// The updated_at trigger is limited to user-editable columns so linking
+		// applications to companies does not look like an edit
+		up: `
+  CREATE TABLE companies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    normalized_name TEXT NOT NULL UNIQUE,
+    size TEXT NOT NULL DEFAULT '',
+    industry TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_companies_updated_at
+  AFTER UPDATE ON companies
+  BEGIN
+    UPDATE companies SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  CREATE TABLE company_aliases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    alias TEXT NOT NULL,
+    normalized_alias TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+
+  ALTER TABLE job_applications ADD COLUMN company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL;
+
+  CREATE INDEX idx_job_applications_company_id ON job_applications(company_id);
+
+  DROP TRIGGER update_job_applications_updated_at;
+
+  CREATE TRIGGER update_job_applications_updated_at
+  AFTER UPDATE OF date_applied, job_title, company, status, job_url, notes ON job_applications
+  BEGIN
+    UPDATE job_applications SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+  `,
+		apply: linkExistingCompanies,
+	},
+	{
+		version:     7,
+		description: "create interviews table",
+		up: `
+  CREATE TABLE interviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    round TEXT NOT NULL,
+    scheduled_at DATETIME NOT NULL,
+    duration_minutes INTEGER NOT NULL DEFAULT 60,
+    location TEXT NOT NULL DEFAULT '',
+    interviewers TEXT NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL DEFAULT 'Scheduled',
+    feedback TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_interviews_job_application_id ON interviews(job_application_id);
+  CREATE INDEX idx_interviews_scheduled_at ON interviews(scheduled_at);
+
+  CREATE TRIGGER update_interviews_updated_at
+  AFTER UPDATE ON interviews
+  BEGIN
+    UPDATE interviews SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+  `,
+	},
+	{
+		version:     8,
+		description: "add follow-up date to job_applications",
+		up: `
+  ALTER TABLE job_applications ADD COLUMN next_action_date DATE;
+
+  CREATE INDEX idx_job_applications_next_action_date ON job_applications(next_action_date);
+
+  DROP TRIGGER update_job_applications_updated_at;
+
+  CREATE TRIGGER update_job_applications_updated_at
+  AFTER UPDATE OF date_applied, job_title, company, status, job_url, notes, next_action_date ON job_applications
+  BEGIN
+    UPDATE job_applications SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+  `,
+	},
+	{
+		version:     9,
+		description: "create follow_up_rules table",
+		up: `
+  CREATE TABLE follow_up_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    status TEXT NOT NULL UNIQUE,
+    follow_up_days INTEGER NOT NULL,
+    no_response_days INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_follow_up_rules_updated_at
+  AFTER UPDATE ON follow_up_rules
+  BEGIN
+    UPDATE follow_up_rules SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  INSERT INTO follow_up_rules (status, follow_up_days) VALUES ('Applied', 10), ('In Review', 7);
+  `,
+	},
+	{
+		version:     10,
+		description: "create statuses and status_transitions tables",
+		up: `
+  CREATE TABLE statuses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    position INTEGER NOT NULL,
+    color TEXT NOT NULL DEFAULT '#3498db',
+    category TEXT NOT NULL DEFAULT 'active' CHECK (category IN ('active', 'closed', 'success')),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TABLE status_transitions (
+    from_status_id INTEGER NOT NULL REFERENCES statuses(id) ON DELETE CASCADE,
+    to_status_id INTEGER NOT NULL REFERENCES statuses(id) ON DELETE CASCADE,
+    PRIMARY KEY (from_status_id, to_status_id)
+  );
+
+  CREATE TRIGGER update_statuses_updated_at
+  AFTER UPDATE ON statuses
+  BEGIN
+    UPDATE statuses SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  INSERT INTO statuses (name, position, color, category) VALUES
+    ('Applied', 1, '#3498db', 'active'),
+    ('In Review', 2, '#f39c12', 'active'),
+    ('Phone Screen', 3, '#9b59b6', 'active'),
+    ('Interview', 4, '#e67e22', 'active'),
+    ('Technical Test', 5, '#8e44ad', 'active'),
+    ('Offer', 6, '#27ae60', 'success'),
+    ('Rejected', 7, '#e74c3c', 'closed'),
+    ('Withdrawn', 8, '#95a5a6', 'closed'),
+    ('No Response', 9, '#7f8c8d', 'closed');
+  `,
+		apply: addExistingStatuses,
+	},
+	{
+		version:     11,
+		description: "create tags and job_application_tags tables",
+		up: `
+  CREATE TABLE tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TABLE job_application_tags (
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (job_application_id, tag_id)
+  );
+
+  CREATE INDEX idx_job_application_tags_tag_id ON job_application_tags(tag_id);
+  `,
+		apply: tagExistingHashtags,
+	},
+	{
+		version:     12,
+		description: "add compensation, location and work mode to job_applications",
+		up: `
+  ALTER TABLE job_applications ADD COLUMN salary_min INTEGER;
+  ALTER TABLE job_applications ADD COLUMN salary_max INTEGER;
+  ALTER TABLE job_applications ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '';
+  ALTER TABLE job_applications ADD COLUMN equity TEXT NOT NULL DEFAULT '';
+  ALTER TABLE job_applications ADD COLUMN location TEXT NOT NULL DEFAULT '';
+  ALTER TABLE job_applications ADD COLUMN work_mode TEXT NOT NULL DEFAULT ''
+    CHECK (work_mode IN ('', 'remote', 'hybrid', 'onsite'));
+
+  CREATE INDEX idx_job_applications_work_mode ON job_applications(work_mode);
+
+  DROP TRIGGER update_job_applications_updated_at;
+
+  CREATE TRIGGER update_job_applications_updated_at
+  AFTER UPDATE OF date_applied, job_title, company, status, job_url, notes, next_action_date,
+    salary_min, salary_max, salary_currency, equity, location, work_mode ON job_applications
+  BEGIN
+    UPDATE job_applications SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+  `,
+	},
+	{
+		version:     13,
+		description: "create attachments table",
+		up: `
+  CREATE TABLE attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL DEFAULT 'Other',
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'application/octet-stream',
+    size INTEGER NOT NULL DEFAULT 0,
+    stored_name TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_attachments_job_application_id ON attachments(job_application_id);
+  `,
+	},
+	{
+		version:     14,
+		description: "create settings and sessions tables",
+		up: `
+  CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL
+  );
+
+  CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
+  `,
+	},
+	{
+		version:     15,
+		description: "create users and give each user their own data",
+		// Everything tracked so far, and any password chosen in the app, goes to a first
+		// admin account. SQLite cannot add a NOT NULL column without a default, so owners
+		// added to existing tables are nullable; companies and tags are rebuilt instead,
+		// since their names become unique per user rather than across the whole database.
+		rebuildsTables: true,
+		up: `
+  CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    password_hash TEXT NOT NULL DEFAULT '',
+    is_admin BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_users_updated_at
+  AFTER UPDATE ON users
+  BEGIN
+    UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  INSERT INTO users (id, username, password_hash, is_admin)
+  VALUES (1, 'admin', COALESCE((SELECT value FROM settings WHERE key = 'password_hash'), ''), 1);
+
+  DELETE FROM settings WHERE key = 'password_hash';
+
+  ALTER TABLE job_applications ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+  UPDATE job_applications SET user_id = 1;
+  CREATE INDEX idx_job_applications_user_id ON job_applications(user_id);
+
+  ALTER TABLE contacts ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+  DROP TRIGGER update_contacts_updated_at;
+  UPDATE contacts SET user_id = 1;
+  CREATE INDEX idx_contacts_user_id ON contacts(user_id);
+
+  CREATE TRIGGER update_contacts_updated_at
+  AFTER UPDATE OF name, role, email, phone, linkedin_url, notes ON contacts
+  BEGIN
+    UPDATE contacts SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  ALTER TABLE sessions ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+  UPDATE sessions SET user_id = 1;
+
+  CREATE TABLE companies_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    normalized_name TEXT NOT NULL,
+    size TEXT NOT NULL DEFAULT '',
+    industry TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, normalized_name)
+  );
+
+  INSERT INTO companies_new (id, user_id, name, normalized_name, size, industry, website, notes, created_at, updated_at)
+  SELECT id, 1, name, normalized_name, size, industry, website, notes, created_at, updated_at FROM companies;
+
+  DROP TABLE companies;
+  ALTER TABLE companies_new RENAME TO companies;
+
+  CREATE TRIGGER update_companies_updated_at
+  AFTER UPDATE ON companies
+  BEGIN
+    UPDATE companies SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+  END;
+
+  CREATE TABLE company_aliases_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    alias TEXT NOT NULL,
+    normalized_alias TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, normalized_alias)
+  );
+
+  INSERT INTO company_aliases_new (id, user_id, company_id, alias, normalized_alias, created_at)
+  SELECT id, 1, company_id, alias, normalized_alias, created_at FROM company_aliases;
+
+  DROP TABLE company_aliases;
+  ALTER TABLE company_aliases_new RENAME TO company_aliases;
+
+  CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+
+  CREATE TABLE tags_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL COLLATE NOCASE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+  );
+
+  INSERT INTO tags_new (id, user_id, name, created_at)
+  SELECT id, 1, name, created_at FROM tags;
+
+  DROP TABLE tags;
+  ALTER TABLE tags_new RENAME TO tags;
+  `,
+	},
+	{
+		version:     16,
+		description: "add roles to statuses",
+		// The app used to find these statuses by name, so they keep their meaning
+		up: `
+  ALTER TABLE statuses ADD COLUMN role TEXT NOT NULL DEFAULT ''
+    CHECK (role IN ('', 'rejection', 'no_response'));
+
+  CREATE UNIQUE INDEX idx_statuses_no_response ON statuses(role) WHERE role = 'no_response';
+
+  UPDATE statuses SET role = 'rejection' WHERE name = 'Rejected';
+  UPDATE statuses SET role = 'no_response' WHERE name = 'No Response';
+  `,
+	},
+	{
+		version:     17,
+		description: "add calendar feed tokens to users",
+		up: `
+  ALTER TABLE users ADD COLUMN calendar_token TEXT NOT NULL DEFAULT '';
+
+  CREATE UNIQUE INDEX idx_users_calendar_token ON users(calendar_token) WHERE calendar_token != '';
+  `,
+	},
+}
+
+// linkExistingCompanies creates a company for every distinct normalized company
+// name and links applications to it. The most used spelling becomes the display name.
+func linkExistingCompanies(ctx context.Context, tx *txn) error {
+	rows, err := tx.QueryContext(ctx, `
+  SELECT company
+  FROM job_applications
+  GROUP BY company
+  ORDER BY COUNT(*) DESC, MIN(id) ASC
+  `)
+	if err != nil {
+		return fmt.Errorf("failed to query company names: %w", err)
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan company name: %w", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read company names: %w", err)
+	}
+
+	// resolveCompanyID has since learned about users, so link with the schema of this version
+	for _, name := range names {
+		normalized := models.NormalizeCompanyName(name)
+		if normalized == "" {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO companies (name, normalized_name) VALUES (?, ?) ON CONFLICT (normalized_name) DO NOTHING`,
+			strings.TrimSpace(name), normalized)
+		if err != nil {
+			return fmt.Errorf("failed to create company: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE job_applications SET company_id = (SELECT id FROM companies WHERE normalized_name = ?) WHERE company = ?`,
+			normalized, name)
+		if err != nil {
+			return fmt.Errorf("failed to link applications to company: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// addExistingStatuses makes the statuses already used by applications valid:
+// spellings differing only in case are changed to the configured name, and
+// any other status is added to the end of the workflow.
+func addExistingStatuses(ctx context.Context, tx *txn) error {
+	_, err := tx.ExecContext(ctx, `
+  INSERT INTO statuses (name, position, color)
+  SELECT status, (SELECT MAX(position) FROM statuses) + ROW_NUMBER() OVER (ORDER BY status), '#34495e'
+  FROM job_applications
+  WHERE status != '' AND status COLLATE NOCASE NOT IN (SELECT name FROM statuses)
+  GROUP BY status COLLATE NOCASE
+  `)
+	if err != nil {
+		return fmt.Errorf("failed to add existing statuses: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+  UPDATE job_applications
+  SET status = (SELECT name FROM statuses WHERE name = job_applications.status)
+  WHERE status NOT IN (SELECT name FROM statuses) AND status COLLATE NOCASE IN (SELECT name FROM statuses)
+  `)
+	if err != nil {
+		return fmt.Errorf("failed to normalize statuses: %w", err)
+	}
+
+	return nil
+}
+
+// hashtag matches a #tag in notes: a # at the start of a word followed by a letter,
+// so URL fragments and "#1" are not mistaken for tags
+var hashtag = regexp.MustCompile(`(?:^|\s)#(\pL[\pL\pN_-]*)`)
+
+// tagExistingHashtags turns the #hashtags people wrote in notes before tags existed
+// into tags. The notes themselves are left unchanged.
+func tagExistingHashtags(ctx context.Context, tx *txn) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id, notes FROM job_applications WHERE notes LIKE '%#%'`)
+	if err != nil {
+		return fmt.Errorf("failed to query notes: %w", err)
+	}
+
+	tagged := make(map[int][]string)
+	for rows.Next() {
+		var id int
+		var notes sql.NullString
+		if err := rows.Scan(&id, &notes); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan notes: %w", err)
+		}
+		for _, match := range hashtag.FindAllStringSubmatch(notes.String, -1) {
+			tagged[id] = append(tagged[id], match[1])
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	// setJobTags has since learned about users, so tag with the schema of this version
+	for id, tags := range tagged {
+		for _, name := range models.NormalizeTags(tags) {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, name); err != nil {
+				return fmt.Errorf("failed to create tag: %w", err)
+			}
+
+			_, err := tx.ExecContext(ctx, `
+  INSERT INTO job_application_tags (job_application_id, tag_id)
+  SELECT ?, id FROM tags WHERE name = ?
+  ON CONFLICT DO NOTHING
+  `, id, name)
+			if err != nil {
+				return fmt.Errorf("failed to tag job application: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LatestSchemaVersion returns the schema version this build of the application expects
+func LatestSchemaVersion() int {
+	return latestVersion(migrations)
+}
+
+// latestVersion returns the version a list of migrations brings the schema to
+func latestVersion(migrations []migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].version
+}
+
+// SchemaVersion returns the version of the most recently applied migration
+func (db *DB) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := db.conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// migrate brings the database schema up to date, applying each pending
+// migration in its own transaction
+func (db *DB) migrate(ctx context.Context) error {
+	d := db.conn.dialect
+
+	// The foreign_keys pragma and PostgreSQL's advisory locks are per connection,
+	// so migrations get a connection of their own
+	conn, err := db.conn.pool.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Another server starting at the same time waits here until this one is done
+	if d.lockMigrations != "" {
+		if _, err := conn.ExecContext(ctx, d.lockMigrations); err != nil {
+			return fmt.Errorf("failed to lock schema_migrations: %w", err)
+		}
+		defer conn.ExecContext(ctx, d.unlockMigrations)
+	}
+
+	if _, err := conn.ExecContext(ctx, d.migrationsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := db.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest := latestVersion(d.migrations)
+	if current > latest {
+		return fmt.Errorf("%w: database is at version %d, application supports up to %d", ErrSchemaTooNew, current, latest)
+	}
+
+	for _, m := range d.migrations {
+		if m.version <= current {
+			continue
+		}
+
+		if err := db.applyMigration(ctx, conn, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.description, err)
+		}
+		log.Printf("Applied database migration %d: %s", m.version, m.description)
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration on conn and records it in schema_migrations
+func (db *DB) applyMigration(ctx context.Context, conn *sql.Conn, m migration) error {
+	if m.rebuildsTables {
+		if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+			return err
+		}
+		defer conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`)
+	}
+
+	sqlTx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlTx.Rollback()
+	tx := &txn{tx: sqlTx, dialect: db.conn.dialect}
+
+	if _, err := tx.ExecContext(ctx, m.up); err != nil {
+		return err
+	}
+
+	if m.apply != nil {
+		if err := m.apply(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if m.rebuildsTables {
+		if err := checkForeignKeys(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, description) VALUES (?, ?)`, m.version, m.description); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkForeignKeys fails if any row refers to a row that does not exist
+func checkForeignKeys(ctx context.Context, tx *txn) error {
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var table string
+		var rowID sql.NullInt64
+		var parent string
+		var index int
+		if err := rows.Scan(&table, &rowID, &parent, &index); err != nil {
+			return fmt.Errorf("failed to scan foreign key violation: %w", err)
+		}
+		return fmt.Errorf("row %d of %s refers to a missing %s", rowID.Int64, table, parent)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,291 @@
+package database
+
+// postgresMigrations lists the schema changes for PostgreSQL databases. PostgreSQL
+// support arrived with schema version 15, so its first migration creates that schema
+// in one step. Later versions must be appended here and to migrations together.
+//
+// Compared with SQLite:
+//   - citext stands in for COLLATE NOCASE, and COLLATE "C" keeps text sorting in
+//     byte order, as SQLite sorts it, whatever the database's locale
+//   - dates are TIMESTAMPTZ, since SQLite keeps the time of day it is given, and
+//     scanJob reads them back in UTC
+//   - the FTS5 table becomes a weighted tsvector column; see searchPostgres
+var postgresMigrations = []migration{
+	{
+		version:     15,
+		description: "create users and give each user their own data",
+		up: `
+  CREATE EXTENSION IF NOT EXISTS citext;
+
+  CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+  BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE TABLE users (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    username CITEXT COLLATE "C" NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL DEFAULT '',
+    is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_users_updated_at
+  BEFORE UPDATE ON users
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  INSERT INTO users (username, is_admin) VALUES ('admin', TRUE);
+
+  CREATE TABLE companies (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT COLLATE "C" NOT NULL,
+    normalized_name TEXT NOT NULL,
+    size TEXT NOT NULL DEFAULT '',
+    industry TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, normalized_name)
+  );
+
+  CREATE TRIGGER update_companies_updated_at
+  BEFORE UPDATE ON companies
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  CREATE TABLE company_aliases (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    alias TEXT NOT NULL,
+    normalized_alias TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, normalized_alias)
+  );
+
+  CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+
+  CREATE TABLE job_applications (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date_applied TIMESTAMPTZ NOT NULL,
+    job_title TEXT COLLATE "C" NOT NULL,
+    company TEXT COLLATE "C" NOT NULL,
+    status TEXT COLLATE "C" NOT NULL DEFAULT 'Applied',
+    job_url TEXT,
+    notes TEXT,
+    company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL,
+    next_action_date TIMESTAMPTZ,
+    salary_min INTEGER,
+    salary_max INTEGER,
+    salary_currency TEXT NOT NULL DEFAULT '',
+    equity TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    work_mode TEXT NOT NULL DEFAULT '' CHECK (work_mode IN ('', 'remote', 'hybrid', 'onsite')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    -- Punctuation becomes spaces so URLs and hyphenated words index as separate words
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+      setweight(to_tsvector('simple', regexp_replace(COALESCE(job_title, '') COLLATE "default", '[^[:alnum:]]+', ' ', 'g')), 'A') ||
+      setweight(to_tsvector('simple', regexp_replace(COALESCE(company, '') COLLATE "default", '[^[:alnum:]]+', ' ', 'g')), 'B') ||
+      setweight(to_tsvector('simple', regexp_replace(COALESCE(notes, '') COLLATE "default", '[^[:alnum:]]+', ' ', 'g')), 'C') ||
+      setweight(to_tsvector('simple', regexp_replace(COALESCE(job_url, '') COLLATE "default", '[^[:alnum:]]+', ' ', 'g')), 'D')
+    ) STORED
+  );
+
+  CREATE INDEX idx_job_applications_user_id ON job_applications(user_id);
+  CREATE INDEX idx_job_applications_date_applied ON job_applications(date_applied);
+  CREATE INDEX idx_job_applications_company ON job_applications(LOWER(company));
+  CREATE INDEX idx_job_applications_status ON job_applications(status);
+  CREATE INDEX idx_job_applications_updated_at ON job_applications(updated_at);
+  CREATE INDEX idx_job_applications_company_id ON job_applications(company_id);
+  CREATE INDEX idx_job_applications_next_action_date ON job_applications(next_action_date);
+  CREATE INDEX idx_job_applications_work_mode ON job_applications(work_mode);
+  CREATE INDEX idx_job_applications_search_vector ON job_applications USING GIN (search_vector);
+
+  -- Only edits to the application itself count as an update, as in SQLite
+  CREATE TRIGGER update_job_applications_updated_at
+  BEFORE UPDATE OF date_applied, job_title, company, status, job_url, notes, next_action_date,
+    salary_min, salary_max, salary_currency, equity, location, work_mode ON job_applications
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  CREATE TABLE status_events (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL,
+    changed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_status_events_job_application_id ON status_events(job_application_id, changed_at);
+
+  CREATE TABLE contacts (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT COLLATE "C" NOT NULL,
+    role TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    linkedin_url TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_contacts_name ON contacts(LOWER(name));
+  CREATE INDEX idx_contacts_user_id ON contacts(user_id);
+
+  CREATE TRIGGER update_contacts_updated_at
+  BEFORE UPDATE OF name, role, email, phone, linkedin_url, notes ON contacts
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  CREATE TABLE job_application_contacts (
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    contact_id INTEGER NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_application_id, contact_id)
+  );
+
+  CREATE INDEX idx_job_application_contacts_contact_id ON job_application_contacts(contact_id);
+
+  CREATE TABLE interviews (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    round TEXT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    duration_minutes INTEGER NOT NULL DEFAULT 60,
+    location TEXT NOT NULL DEFAULT '',
+    interviewers TEXT NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL DEFAULT 'Scheduled',
+    feedback TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_interviews_job_application_id ON interviews(job_application_id);
+  CREATE INDEX idx_interviews_scheduled_at ON interviews(scheduled_at);
+
+  CREATE TRIGGER update_interviews_updated_at
+  BEFORE UPDATE ON interviews
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  CREATE TABLE follow_up_rules (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    status TEXT COLLATE "C" NOT NULL UNIQUE,
+    follow_up_days INTEGER NOT NULL,
+    no_response_days INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_follow_up_rules_updated_at
+  BEFORE UPDATE ON follow_up_rules
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  INSERT INTO follow_up_rules (status, follow_up_days) VALUES
+    ('Applied', 10),
+    ('In Review', 7);
+
+  CREATE TABLE statuses (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name CITEXT COLLATE "C" NOT NULL UNIQUE,
+    position INTEGER NOT NULL,
+    color TEXT NOT NULL DEFAULT '#3498db',
+    category TEXT NOT NULL DEFAULT 'active' CHECK (category IN ('active', 'closed', 'success')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TRIGGER update_statuses_updated_at
+  BEFORE UPDATE ON statuses
+  FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+  INSERT INTO statuses (name, position, color, category) VALUES
+    ('Applied', 1, '#3498db', 'active'),
+    ('In Review', 2, '#f39c12', 'active'),
+    ('Phone Screen', 3, '#9b59b6', 'active'),
+    ('Interview', 4, '#e67e22', 'active'),
+    ('Technical Test', 5, '#8e44ad', 'active'),
+    ('Offer', 6, '#27ae60', 'success'),
+    ('Rejected', 7, '#e74c3c', 'closed'),
+    ('Withdrawn', 8, '#95a5a6', 'closed'),
+    ('No Response', 9, '#7f8c8d', 'closed');
+
+  CREATE TABLE status_transitions (
+    from_status_id INTEGER NOT NULL REFERENCES statuses(id) ON DELETE CASCADE,
+    to_status_id INTEGER NOT NULL REFERENCES statuses(id) ON DELETE CASCADE,
+    PRIMARY KEY (from_status_id, to_status_id)
+  );
+
+  CREATE TABLE tags (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name CITEXT COLLATE "C" NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+  );
+
+  CREATE TABLE job_application_tags (
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (job_application_id, tag_id)
+  );
+
+  CREATE INDEX idx_job_application_tags_tag_id ON job_application_tags(tag_id);
+
+  CREATE TABLE attachments (
+    id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    job_application_id INTEGER NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL DEFAULT 'Other',
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'application/octet-stream',
+    size BIGINT NOT NULL DEFAULT 0,
+    stored_name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE INDEX idx_attachments_job_application_id ON attachments(job_application_id);
+
+  CREATE TABLE settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+  );
+
+  CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+  );
+
+  CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
+  `,
+	},
+	{
+		version:     16,
+		description: "add roles to statuses",
+		up: `
+  ALTER TABLE statuses ADD COLUMN role TEXT NOT NULL DEFAULT ''
+    CHECK (role IN ('', 'rejection', 'no_response'));
+
+  CREATE UNIQUE INDEX idx_statuses_no_response ON statuses(role) WHERE role = 'no_response';
+
+  UPDATE statuses SET role = 'rejection' WHERE name = 'Rejected';
+  UPDATE statuses SET role = 'no_response' WHERE name = 'No Response';
+  `,
+	},
+	{
+		version:     17,
+		description: "add calendar feed tokens to users",
+		up: `
+  ALTER TABLE users ADD COLUMN calendar_token TEXT NOT NULL DEFAULT '';
+
+  CREATE UNIQUE INDEX idx_users_calendar_token ON users(calendar_token) WHERE calendar_token != '';
+  `,
+	},
+}
@@ -0,0 +1,325 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"hunter-seeker/internal/models"
+)
+
+// maxSearchResults caps the number of rows a search returns
+const maxSearchResults = 100
+
+// snippetTokens is the number of words a notes snippet shows, as in the FTS5 query
+const snippetTokens = 16
+
+// SearchQuery is free text parsed into the phrases a search must find. Each word
+// of the text is a phrase of its letters and digits, and every phrase must match
+// somewhere in the job title, company, notes or job URL. The last word of each
+// phrase matches as a prefix.
+type SearchQuery struct {
+	phrases [][]string
+}
+
+// ParseSearchQuery parses free text into a SearchQuery
+func ParseSearchQuery(text string) SearchQuery {
+	var query SearchQuery
+	for _, word := range strings.Fields(text) {
+		var phrase []string
+		for _, t := range tokenize(strings.ReplaceAll(word, `"`, "")) {
+			phrase = append(phrase, t.text)
+		}
+		if len(phrase) > 0 {
+			query.phrases = append(query.phrases, phrase)
+		}
+	}
+	return query
+}
+
+// Empty reports whether the query has nothing to search for
+func (q SearchQuery) Empty() bool {
+	return len(q.phrases) == 0
+}
+
+// Match returns the job application with the query's matches highlighted, and
+// reports whether every phrase appears in it. The rank counts the matches, with
+// matches in the title and company counting above matches in notes and URLs;
+// lower ranks are better.
+func (q SearchQuery) Match(job *models.JobApplication) (models.SearchResult, bool) {
+	title := newSearchColumn(job.JobTitle, q.phrases)
+	company := newSearchColumn(job.Company, q.phrases)
+	notes := newSearchColumn(job.Notes, q.phrases)
+	url := newSearchColumn(job.JobURL, q.phrases)
+
+	result := models.SearchResult{
+		Job:              job,
+		TitleHighlight:   title.highlighted(),
+		CompanyHighlight: company.highlighted(),
+		NotesSnippet:     notes.snippet(),
+		Rank:             -float64(10*title.hits + 5*company.hits + notes.hits + url.hits),
+	}
+
+	for _, phrase := range q.phrases {
+		if !title.contains(phrase) && !company.contains(phrase) && !notes.contains(phrase) && !url.contains(phrase) {
+			return result, false
+		}
+	}
+	return result, true
+}
+
+// ftsQuery returns the query as an FTS5 query. Each word is quoted so punctuation
+// and FTS5 operators in user input are treated literally.
+func (q SearchQuery) ftsQuery() string {
+	terms := make([]string, len(q.phrases))
+	for i, phrase := range q.phrases {
+		terms[i] = `"` + strings.Join(phrase, " ") + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// tsQuery returns the query as a PostgreSQL tsquery. Tokens hold only letters and
+// digits, so they need no escaping inside the quotes.
+func (q SearchQuery) tsQuery() string {
+	terms := make([]string, len(q.phrases))
+	for i, phrase := range q.phrases {
+		words := make([]string, len(phrase))
+		for j, word := range phrase {
+			words[j] = "'" + word + "'"
+		}
+		terms[i] = strings.Join(words, " <-> ") + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// token is a word of indexed text: a run of letters and digits, and where it appears
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into lowercase words the way the FTS5 unicode61 tokenizer does,
+// without removing diacritics
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return tokens
+}
+
+// matchPhrase returns the index of each token where the phrase starts
+func matchPhrase(tokens []token, phrase []string) []int {
+	var starts []int
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		matched := true
+		for j, word := range phrase {
+			text := tokens[i+j].text
+			if j == len(phrase)-1 && strings.HasPrefix(text, word) || text == word {
+				continue
+			}
+			matched = false
+			break
+		}
+		if matched {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// searchColumn is a searched field with the tokens the phrases matched
+type searchColumn struct {
+	text    string
+	tokens  []token
+	matched []bool
+	hits    int
+}
+
+func newSearchColumn(text string, phrases [][]string) *searchColumn {
+	column := &searchColumn{text: text, tokens: tokenize(text)}
+	column.matched = make([]bool, len(column.tokens))
+	for _, phrase := range phrases {
+		for _, start := range matchPhrase(column.tokens, phrase) {
+			column.hits++
+			for i := start; i < start+len(phrase); i++ {
+				column.matched[i] = true
+			}
+		}
+	}
+	return column
+}
+
+// contains reports whether a phrase appears in the column
+func (c *searchColumn) contains(phrase []string) bool {
+	return len(matchPhrase(c.tokens, phrase)) > 0
+}
+
+// highlight returns the tokens from first to last, and the text between them,
+// with matched tokens wrapped in the highlight markers
+func (c *searchColumn) highlight(first, last int) string {
+	var b strings.Builder
+	pos := c.tokens[first].start
+	for i := first; i <= last; i++ {
+		t := c.tokens[i]
+		b.WriteString(c.text[pos:t.start])
+		if c.matched[i] {
+			b.WriteString(models.HighlightStart + c.text[t.start:t.end] + models.HighlightEnd)
+		} else {
+			b.WriteString(c.text[t.start:t.end])
+		}
+		pos = t.end
+	}
+	return b.String()
+}
+
+// highlighted returns the whole text with matched tokens highlighted
+func (c *searchColumn) highlighted() string {
+	if len(c.tokens) == 0 {
+		return c.text
+	}
+	last := len(c.tokens) - 1
+	return c.text[:c.tokens[0].start] + c.highlight(0, last) + c.text[c.tokens[last].end:]
+}
+
+// snippet returns up to snippetTokens tokens around the first match, highlighted,
+// with an ellipsis where the text is cut
+func (c *searchColumn) snippet() string {
+	if len(c.tokens) <= snippetTokens {
+		return c.highlighted()
+	}
+
+	first := 0
+	for i, matched := range c.matched {
+		if matched {
+			first = max(0, i-snippetTokens/4)
+			break
+		}
+	}
+	first = min(first, len(c.tokens)-snippetTokens)
+	last := first + snippetTokens - 1
+
+	snippet := c.highlight(first, last)
+	if first == 0 {
+		snippet = c.text[:c.tokens[0].start] + snippet
+	} else {
+		snippet = "…" + snippet
+	}
+	if last == len(c.tokens)-1 {
+		snippet += c.text[c.tokens[last].end:]
+	} else {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// SearchJobApplications runs a full-text search over job title, company, notes and
+// job URL, optionally limited to a status. Results are ordered by relevance, with
+// matches in the title and company weighted above matches in notes and URLs.
+func (db *DB) SearchJobApplications(ctx context.Context, text, status string) ([]models.SearchResult, error) {
+	query := ParseSearchQuery(text)
+	if query.Empty() {
+		return nil, nil
+	}
+	return db.conn.dialect.search(ctx, db, query, status)
+}
+
+// searchSQLite searches the FTS5 index, which also highlights the matches
+func searchSQLite(ctx context.Context, db *DB, search SearchQuery, status string) ([]models.SearchResult, error) {
+	query := `
+  SELECT ` + jobColumns("j") + `,
+    highlight(job_applications_fts, 0, ?, ?),
+    highlight(job_applications_fts, 1, ?, ?),
+    snippet(job_applications_fts, 2, ?, ?, '…', ` + fmt.Sprint(snippetTokens) + `),
+    bm25(job_applications_fts, 10.0, 5.0, 1.0, 1.0) AS rank
+  FROM job_applications_fts
+  JOIN job_applications j ON j.id = job_applications_fts.rowid
+  WHERE job_applications_fts MATCH ? AND j.user_id = ?
+  `
+	args := []interface{}{
+		models.HighlightStart, models.HighlightEnd,
+		models.HighlightStart, models.HighlightEnd,
+		models.HighlightStart, models.HighlightEnd,
+		search.ftsQuery(), db.userID,
+	}
+
+	if status != "" {
+		query += "  AND j.status = ?\n"
+		args = append(args, status)
+	}
+
+	query += fmt.Sprintf("  ORDER BY rank ASC, j.date_applied DESC\n  LIMIT %d\n", maxSearchResults)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search job applications: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var result models.SearchResult
+		job, err := scanJob(rows, &result.TitleHighlight, &result.CompanyHighlight, &result.NotesSnippet, &result.Rank)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Job = job
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// searchPostgres finds and ranks matches with the search_vector index, then
+// highlights them in Go, since ts_headline would strip the markup notes may contain.
+// Unlike FTS5, PostgreSQL's simple configuration does not fold diacritics.
+func searchPostgres(ctx context.Context, db *DB, search SearchQuery, status string) ([]models.SearchResult, error) {
+	query := `
+  SELECT ` + jobColumns("") + `,
+    -ts_rank('{0.1, 0.1, 0.5, 1.0}', search_vector, to_tsquery('simple', ?)) AS rank
+  FROM job_applications
+  WHERE search_vector @@ to_tsquery('simple', ?) AND user_id = ?
+  `
+	tsQuery := search.tsQuery()
+	args := []interface{}{tsQuery, tsQuery, db.userID}
+
+	if status != "" {
+		query += "  AND status = ?\n"
+		args = append(args, status)
+	}
+
+	query += fmt.Sprintf("  ORDER BY rank ASC, date_applied DESC\n  LIMIT %d\n", maxSearchResults)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search job applications: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var rank float64
+		job, err := scanJob(rows, &rank)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		// The index and Match split words alike, so this only highlights
+		result, _ := search.Match(job)
+		result.Rank = rank
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"hunter-seeker/internal/auth"
+	"hunter-seeker/internal/models"
+)
+
+// CreateSession records a user's login session by the hash of its token
+func (db *DB) CreateSession(ctx context.Context, tokenHash string, userID int, expiresAt time.Time) error {
+	_, err := db.conn.ExecContext(ctx, `INSERT INTO sessions (token_hash, user_id, expires_at) VALUES (?, ?, ?)`, tokenHash, userID, expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// SessionUser returns the user logged in with a session, or nil if the session
+// does not exist or expired before now
+func (db *DB) SessionUser(ctx context.Context, tokenHash string, now time.Time) (*models.User, error) {
+	query := `
+  SELECT u.id, u.username, u.password_hash, u.is_admin, u.created_at, u.updated_at
+  FROM sessions s
+  JOIN users u ON u.id = s.user_id
+  WHERE s.token_hash = ? AND s.expires_at > ?
+  `
+
+	user, err := scanUser(db.conn.QueryRowContext(ctx, query, tokenHash, now.UTC()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check session: %w", err)
+	}
+	return user, nil
+}
+
+// DeleteSession ends a login session. Ending a session that does not exist is not an error.
+func (db *DB) DeleteSession(ctx context.Context, tokenHash string) error {
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM sessions WHERE token_hash = ?`, tokenHash); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredSessions removes sessions that expired before now
+func (db *DB) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at <= ?`, now.UTC()); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return nil
+}
+
+// CalendarToken returns the secret token that opens the user's calendar feed without
+// a login, creating it the first time. Unlike session tokens it is stored as is, so
+// the feed's address can be shown again.
+func (db *DB) CalendarToken(ctx context.Context) (string, error) {
+	token, err := auth.NewSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	// Should two requests create a token at once, the first one saved is kept
+	if _, err := db.conn.ExecContext(ctx, `UPDATE users SET calendar_token = ? WHERE id = ? AND calendar_token = ''`, token, db.userID); err != nil {
+		return "", fmt.Errorf("failed to create calendar token: %w", err)
+	}
+
+	err = db.conn.QueryRowContext(ctx, `SELECT calendar_token FROM users WHERE id = ?`, db.userID).Scan(&token)
+	if err == sql.ErrNoRows {
+		return "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	return token, nil
+}
+
+// CalendarTokenUser returns the user whose calendar feed a token opens, or nil if none does
+func (db *DB) CalendarTokenUser(ctx context.Context, token string) (*models.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	user, err := scanUser(db.conn.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE calendar_token = ?`, token))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check calendar token: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// recordStatusEvent stores a status change as part of an enclosing transaction
+func recordStatusEvent(ctx context.Context, tx *txn, jobID int, fromStatus, toStatus string) error {
+	query := `
+  INSERT INTO status_events (job_application_id, from_status, to_status)
+  VALUES (?, ?, ?)
+  `
+
+	if _, err := tx.ExecContext(ctx, query, jobID, fromStatus, toStatus); err != nil {
+		return fmt.Errorf("failed to record status event: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatusHistory retrieves the status changes of a job application, oldest first
+func (db *DB) GetStatusHistory(ctx context.Context, jobID int) ([]models.StatusEvent, error) {
+	query := `
+  SELECT e.id, e.job_application_id, e.from_status, e.to_status, e.changed_at
+  FROM status_events e
+  JOIN job_applications j ON j.id = e.job_application_id
+  WHERE e.job_application_id = ? AND j.user_id = ?
+  ORDER BY e.changed_at ASC, e.id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, jobID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.StatusEvent
+	for rows.Next() {
+		var event models.StatusEvent
+		err := rows.Scan(&event.ID, &event.JobApplicationID, &event.FromStatus, &event.ToStatus, &event.ChangedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetJobApplicationsWithHistory retrieves job applications applied for between from and to
+// (inclusive calendar dates, zero means unbounded), each with its status history loaded
+func (db *DB) GetJobApplicationsWithHistory(ctx context.Context, from, to time.Time) ([]*models.JobApplication, error) {
+	where, args := db.historyWhere("", from, to)
+
+	query := `
+  SELECT ` + jobColumns("") + `
+  FROM job_applications
+  ` + where + `
+  ORDER BY date_applied ASC, id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job applications: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.JobApplication
+	byID := make(map[int]*models.JobApplication)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		jobs = append(jobs, job)
+		byID[job.ID] = job
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read job applications: %w", err)
+	}
+
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	eventWhere, eventArgs := db.historyWhere("j.", from, to)
+	eventQuery := `
+  SELECT e.id, e.job_application_id, e.from_status, e.to_status, e.changed_at
+  FROM status_events e
+  JOIN job_applications j ON j.id = e.job_application_id
+  ` + eventWhere + `
+  ORDER BY e.changed_at ASC, e.id ASC
+  `
+
+	eventRows, err := db.conn.QueryContext(ctx, eventQuery, eventArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status events: %w", err)
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var event models.StatusEvent
+		err := eventRows.Scan(&event.ID, &event.JobApplicationID, &event.FromStatus, &event.ToStatus, &event.ChangedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		if job, ok := byID[event.JobApplicationID]; ok {
+			job.History = append(job.History, event)
+		}
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status events: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// historyWhere returns the WHERE clause of GetJobApplicationsWithHistory and its
+// arguments, with the job_applications columns prefixed by a table alias such as "j."
+func (db *DB) historyWhere(prefix string, from, to time.Time) (string, []interface{}) {
+	conditions := []string{prefix + "user_id = ?"}
+	args := []interface{}{db.userID}
+	if !from.IsZero() {
+		conditions = append(conditions, prefix+"date_applied >= ?")
+		args = append(args, from)
+	}
+	if !to.IsZero() {
+		conditions = append(conditions, prefix+"date_applied < ?")
+		args = append(args, to.AddDate(0, 0, 1))
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,421 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"hunter-seeker/internal/models"
+)
+
+// Status errors
+var (
+	ErrStatusNotFound       = errors.New("status not found")
+	ErrStatusNameTaken      = errors.New("another status already has this name")
+	ErrStatusInUse          = errors.New("status is used by job applications")
+	ErrInvalidStatus        = errors.New("unknown status")
+	ErrTransitionNotAllowed = errors.New("status transition not allowed")
+)
+
+// GetStatuses retrieves the configured statuses in workflow order,
+// with their allowed transitions and how many of the user's applications use them
+func (db *DB) GetStatuses(ctx context.Context) ([]*models.Status, error) {
+	query := `
+  SELECT s.id, s.name, s.position, s.color, s.category, s.role, COUNT(j.id)
+  FROM statuses s
+  LEFT JOIN job_applications j ON j.status = s.name AND j.user_id = ?
+  GROUP BY s.id
+  ORDER BY s.position ASC, s.id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []*models.Status
+	byID := make(map[int]*models.Status)
+	for rows.Next() {
+		status := &models.Status{}
+		err := rows.Scan(&status.ID, &status.Name, &status.Position, &status.Color, &status.Category, &status.Role, &status.ApplicationCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status: %w", err)
+		}
+		statuses = append(statuses, status)
+		byID[status.ID] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statuses: %w", err)
+	}
+
+	transitions, err := db.conn.QueryContext(ctx, `
+  SELECT t.from_status_id, s.name
+  FROM status_transitions t
+  JOIN statuses s ON s.id = t.to_status_id
+  ORDER BY s.position ASC, s.id ASC
+  `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status transitions: %w", err)
+	}
+	defer transitions.Close()
+
+	for transitions.Next() {
+		var fromID int
+		var to string
+		if err := transitions.Scan(&fromID, &to); err != nil {
+			return nil, fmt.Errorf("failed to scan status transition: %w", err)
+		}
+		if status, ok := byID[fromID]; ok {
+			status.Transitions = append(status.Transitions, to)
+		}
+	}
+
+	return statuses, transitions.Err()
+}
+
+// GetStatus retrieves a single status by ID
+func (db *DB) GetStatus(ctx context.Context, id int) (*models.Status, error) {
+	statuses, err := db.GetStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range statuses {
+		if status.ID == id {
+			return status, nil
+		}
+	}
+
+	return nil, ErrStatusNotFound
+}
+
+// GetStatusNames returns the names of the configured statuses in workflow order
+func (db *DB) GetStatusNames(ctx context.Context) ([]string, error) {
+	rows, err := db.conn.QueryContext(ctx, `SELECT name FROM statuses ORDER BY position ASC, id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan status: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// CreateStatus adds a status to the end of the workflow. Giving it the no-response
+// role takes the role from the status that had it.
+func (db *DB) CreateStatus(ctx context.Context, status *models.Status) error {
+	query := `
+  INSERT INTO statuses (name, position, color, category, role)
+  VALUES (?, (SELECT COALESCE(MAX(position), 0) + 1 FROM statuses), ?, ?, ?)
+  RETURNING id, position
+  `
+
+	if err := db.checkStatusName(ctx, status.Name, 0); err != nil {
+		return err
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := takeRole(ctx, tx, status.Role, 0); err != nil {
+		return err
+	}
+
+	if err := tx.QueryRowContext(ctx, query, status.Name, status.Color, status.Category, status.Role).Scan(&status.ID, &status.Position); err != nil {
+		return fmt.Errorf("failed to create status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus saves a status's name, color, category and role. Renaming a status
+// renames it everywhere it is used, including the history of applications, and
+// giving it the no-response role takes the role from the status that had it.
+func (db *DB) UpdateStatus(ctx context.Context, status *models.Status) error {
+	if err := db.checkStatusName(ctx, status.Name, status.ID); err != nil {
+		return err
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	if err := tx.QueryRowContext(ctx, `SELECT name FROM statuses WHERE id = ?`, status.ID).Scan(&oldName); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStatusNotFound
+		}
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	if err := takeRole(ctx, tx, status.Role, status.ID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE statuses SET name = ?, color = ?, category = ?, role = ? WHERE id = ?`,
+		status.Name, status.Color, status.Category, status.Role, status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if oldName != status.Name {
+		renames := []string{
+			`UPDATE job_applications SET status = ? WHERE status = ?`,
+			`UPDATE status_events SET from_status = ? WHERE from_status = ?`,
+			`UPDATE status_events SET to_status = ? WHERE to_status = ?`,
+			`UPDATE follow_up_rules SET status = ? WHERE status = ?`,
+		}
+		for _, query := range renames {
+			if _, err := tx.ExecContext(ctx, query, status.Name, oldName); err != nil {
+				return fmt.Errorf("failed to rename status: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status update: %w", err)
+	}
+
+	return nil
+}
+
+// MoveStatus swaps a status with its neighbour earlier (negative offset) or later
+// (positive offset) in the workflow. Moving past either end does nothing.
+func (db *DB) MoveStatus(ctx context.Context, id int, offset int) error {
+	statuses, err := db.GetStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, status := range statuses {
+		if status.ID == id {
+			index = i
+		}
+	}
+	if index == -1 {
+		return ErrStatusNotFound
+	}
+
+	target := index + offset
+	if offset == 0 || target < 0 || target >= len(statuses) {
+		return nil
+	}
+
+	// Renumber the whole list so positions stay unique and contiguous
+	statuses[index], statuses[target] = statuses[target], statuses[index]
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, status := range statuses {
+		if _, err := tx.ExecContext(ctx, `UPDATE statuses SET position = ? WHERE id = ?`, i+1, status.ID); err != nil {
+			return fmt.Errorf("failed to reorder statuses: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status order: %w", err)
+	}
+
+	return nil
+}
+
+// SetStatusTransitions replaces the statuses an application may move to from a status.
+// An empty list allows any transition.
+func (db *DB) SetStatusTransitions(ctx context.Context, id int, toIDs []int) error {
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM statuses WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check status: %w", err)
+	}
+	if !exists {
+		return ErrStatusNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM status_transitions WHERE from_status_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to clear status transitions: %w", err)
+	}
+
+	for _, toID := range toIDs {
+		if toID == id {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+  INSERT INTO status_transitions (from_status_id, to_status_id)
+  SELECT CAST(? AS INTEGER), id FROM statuses WHERE id = ?
+  ON CONFLICT DO NOTHING
+  `, id, toID)
+		if err != nil {
+			return fmt.Errorf("failed to save status transition: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status transitions: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStatus deletes a status that no application, of any user, is using,
+// along with its transitions and follow-up rule
+func (db *DB) DeleteStatus(ctx context.Context, id int) error {
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var name string
+	if err := tx.QueryRowContext(ctx, `SELECT name FROM statuses WHERE id = ?`, id).Scan(&name); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStatusNotFound
+		}
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	var inUse bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM job_applications WHERE status = ?)`, name).Scan(&inUse); err != nil {
+		return fmt.Errorf("failed to check status usage: %w", err)
+	}
+	if inUse {
+		return ErrStatusInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM follow_up_rules WHERE status = ?`, name); err != nil {
+		return fmt.Errorf("failed to delete follow-up rule: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM statuses WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status deletion: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveStatus returns the configured spelling of a status name, matched
+// case-insensitively, or ErrInvalidStatus if there is no such status
+func (db *DB) ResolveStatus(ctx context.Context, name string) (string, error) {
+	return resolveStatus(ctx, db.conn, name)
+}
+
+// DefaultStatus returns the first status of the workflow, used when none is given
+func (db *DB) DefaultStatus(ctx context.Context) (string, error) {
+	var name string
+	err := db.conn.QueryRowContext(ctx, `SELECT name FROM statuses ORDER BY position ASC, id ASC LIMIT 1`).Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrInvalidStatus
+		}
+		return "", fmt.Errorf("failed to get default status: %w", err)
+	}
+	return name, nil
+}
+
+// takeRole clears a role only one status may have from every status but id
+func takeRole(ctx context.Context, tx *txn, role string, id int) error {
+	if role != models.RoleNoResponse {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE statuses SET role = '' WHERE role = ? AND id != ?`, role, id); err != nil {
+		return fmt.Errorf("failed to clear status role: %w", err)
+	}
+	return nil
+}
+
+// noResponseStatus returns the name of the status with the no-response role,
+// or "" if no status has it
+func noResponseStatus(ctx context.Context, q queryRower) (string, error) {
+	var name string
+	err := q.QueryRowContext(ctx, `SELECT name FROM statuses WHERE role = ?`, models.RoleNoResponse).Scan(&name)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to get no-response status: %w", err)
+	}
+	return name, nil
+}
+
+func resolveStatus(ctx context.Context, q queryRower, name string) (string, error) {
+	var resolved string
+	err := q.QueryRowContext(ctx, `SELECT name FROM statuses WHERE name = ?`, name).Scan(&resolved)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%w %q", ErrInvalidStatus, name)
+		}
+		return "", fmt.Errorf("failed to check status: %w", err)
+	}
+	return resolved, nil
+}
+
+// checkTransition verifies that an application may move from one status to another
+func checkTransition(ctx context.Context, tx *txn, from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	query := `
+  SELECT
+    EXISTS (SELECT 1 FROM status_transitions WHERE from_status_id = f.id),
+    EXISTS (SELECT 1 FROM status_transitions t JOIN statuses s ON s.id = t.to_status_id WHERE t.from_status_id = f.id AND s.name = ?)
+  FROM statuses f
+  WHERE f.name = ?
+  `
+
+	var restricted, allowed bool
+	if err := tx.QueryRowContext(ctx, query, to, from).Scan(&restricted, &allowed); err != nil {
+		if err == sql.ErrNoRows {
+			// Applications in a status that no longer exists may move anywhere
+			return nil
+		}
+		return fmt.Errorf("failed to check status transition: %w", err)
+	}
+
+	if restricted && !allowed {
+		return fmt.Errorf("%w from %q to %q", ErrTransitionNotAllowed, from, to)
+	}
+	return nil
+}
+
+// checkStatusName verifies that no other status has the given name
+func (db *DB) checkStatusName(ctx context.Context, name string, id int) error {
+	var taken bool
+	err := db.conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM statuses WHERE name = ? AND id != ?)`, name, id).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("failed to check status name: %w", err)
+	}
+	if taken {
+		return ErrStatusNameTaken
+	}
+	return nil
+}
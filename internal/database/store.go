@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"hunter-seeker/internal/followup"
+	"hunter-seeker/internal/models"
+)
+
+// Store is the data layer the handlers work with. Like DB, a Store reads and writes
+// one user's job applications, contacts, companies, tags and attachments, while
+// statuses, follow-up rules, users and sessions are shared.
+// Implementations return the errors declared in this package, such as ErrJobNotFound.
+// Methods given a context stop and return its error once it is done.
+type Store interface {
+	// ForUser returns a Store sharing the same data that works with another user's
+	ForUser(userID int) Store
+	// UserID returns the user whose data the Store works with
+	UserID() int
+	Close() error
+
+	// Job applications
+	CreateJobApplication(ctx context.Context, job *models.JobApplication) error
+	GetJobApplication(ctx context.Context, id int) (*models.JobApplication, error)
+	GetAllJobApplications(ctx context.Context) ([]*models.JobApplication, error)
+	UpdateJobApplication(ctx context.Context, job *models.JobApplication) error
+	DeleteJobApplication(ctx context.Context, id int) error
+	GetJobApplicationsByStatus(ctx context.Context, status string) ([]*models.JobApplication, error)
+	GetJobApplicationsWithFollowUp(ctx context.Context) ([]*models.JobApplication, error)
+	GetStatusCounts(ctx context.Context) (map[string]int, error)
+	GetTotalJobApplicationCount(ctx context.Context) (int, error)
+	FindDuplicateJobApplication(ctx context.Context, job *models.JobApplication) (*models.JobApplication, error)
+	ListJobApplications(ctx context.Context, opts ListOptions) ([]*models.JobApplication, int, error)
+	SearchJobApplications(ctx context.Context, text, status string) ([]models.SearchResult, error)
+	GetStatusHistory(ctx context.Context, jobID int) ([]models.StatusEvent, error)
+	GetJobApplicationsWithHistory(ctx context.Context, from, to time.Time) ([]*models.JobApplication, error)
+
+	// Tags
+	GetTags(ctx context.Context) ([]*models.Tag, error)
+	GetTagsForJob(ctx context.Context, jobID int) ([]string, error)
+
+	// Companies
+	GetAllCompanies(ctx context.Context) ([]*models.Company, error)
+	GetCompany(ctx context.Context, id int) (*models.Company, error)
+	FindCompanyByName(ctx context.Context, name string) (*models.Company, error)
+	UpdateCompany(ctx context.Context, company *models.Company) error
+	AddCompanyAlias(ctx context.Context, companyID int, alias string) error
+	RemoveCompanyAlias(ctx context.Context, companyID, aliasID int) error
+
+	// Contacts
+	CreateContact(ctx context.Context, contact *models.Contact) error
+	GetContact(ctx context.Context, id int) (*models.Contact, error)
+	GetAllContacts(ctx context.Context) ([]*models.Contact, error)
+	UpdateContact(ctx context.Context, contact *models.Contact) error
+	DeleteContact(ctx context.Context, id int) error
+	GetContactsForJob(ctx context.Context, jobID int) ([]*models.Contact, error)
+	GetJobApplicationsForContact(ctx context.Context, contactID int) ([]*models.JobApplication, error)
+	LinkContact(ctx context.Context, jobID, contactID int) error
+	UnlinkContact(ctx context.Context, jobID, contactID int) error
+
+	// Interviews
+	CreateInterview(ctx context.Context, interview *models.Interview) error
+	GetInterview(ctx context.Context, id int) (*models.Interview, error)
+	UpdateInterview(ctx context.Context, interview *models.Interview) error
+	DeleteInterview(ctx context.Context, id int) error
+	GetInterviewsForJob(ctx context.Context, jobID int) ([]*models.Interview, error)
+	GetUpcomingInterviews(ctx context.Context, now time.Time, limit int) ([]*models.Interview, error)
+	GetAllInterviews(ctx context.Context) ([]*models.Interview, error)
+
+	// Attachments
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, content io.Reader) error
+	GetAttachment(ctx context.Context, id int) (*models.Attachment, error)
+	GetAttachmentsForJob(ctx context.Context, jobID int) ([]*models.Attachment, error)
+	OpenAttachment(attachment *models.Attachment) (io.ReadSeekCloser, error)
+	DeleteAttachment(ctx context.Context, id int) error
+
+	// Follow-ups
+	GetFollowUpRules(ctx context.Context) ([]*models.FollowUpRule, error)
+	SaveFollowUpRule(ctx context.Context, rule *models.FollowUpRule) error
+	DeleteFollowUpRule(ctx context.Context, id int) error
+	SetNextActionDate(ctx context.Context, id int, date *time.Time) error
+	GetApplicationsNeedingAttention(ctx context.Context, now time.Time) ([]followup.Item, error)
+	MoveSilentApplications(ctx context.Context, now time.Time) (int, error)
+
+	// Statuses
+	GetStatuses(ctx context.Context) ([]*models.Status, error)
+	GetStatus(ctx context.Context, id int) (*models.Status, error)
+	GetStatusNames(ctx context.Context) ([]string, error)
+	CreateStatus(ctx context.Context, status *models.Status) error
+	UpdateStatus(ctx context.Context, status *models.Status) error
+	MoveStatus(ctx context.Context, id int, offset int) error
+	SetStatusTransitions(ctx context.Context, id int, toIDs []int) error
+	DeleteStatus(ctx context.Context, id int) error
+	ResolveStatus(ctx context.Context, name string) (string, error)
+	DefaultStatus(ctx context.Context) (string, error)
+
+	// Users
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	DefaultUser(ctx context.Context) (*models.User, error)
+	AdminHasPassword(ctx context.Context) (bool, error)
+	CreateUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, user *models.User) error
+	SetUserPassword(ctx context.Context, id int, hash string) error
+	DeleteUser(ctx context.Context, id int) error
+
+	// Sessions
+	CreateSession(ctx context.Context, tokenHash string, userID int, expiresAt time.Time) error
+	SessionUser(ctx context.Context, tokenHash string, now time.Time) (*models.User, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+	CalendarToken(ctx context.Context) (string, error)
+	CalendarTokenUser(ctx context.Context, token string) (*models.User, error)
+}
+
+// DB is the SQL-backed Store, for both SQLite and PostgreSQL
+var _ Store = (*DB)(nil)
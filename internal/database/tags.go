@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// GetTags retrieves every tag in use, alphabetically, with the number of applications using it
+func (db *DB) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	query := `
+  SELECT t.id, t.name, COUNT(jt.job_application_id)
+  FROM tags t
+  JOIN job_application_tags jt ON jt.tag_id = t.id
+  WHERE t.user_id = ?
+  GROUP BY t.id
+  ORDER BY LOWER(t.name) ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*models.Tag
+	for rows.Next() {
+		tag := &models.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.ApplicationCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetTagsForJob retrieves the names of a job application's tags, alphabetically
+func (db *DB) GetTagsForJob(ctx context.Context, jobID int) ([]string, error) {
+	query := `
+  SELECT t.name
+  FROM tags t
+  JOIN job_application_tags jt ON jt.tag_id = t.id
+  WHERE jt.job_application_id = ? AND t.user_id = ?
+  ORDER BY LOWER(t.name) ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, jobID, db.userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, name)
+	}
+
+	return tags, rows.Err()
+}
+
+// attachTags loads the tags of a list of job applications with a single query
+func (db *DB) attachTags(ctx context.Context, jobs []*models.JobApplication) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*models.JobApplication, len(jobs))
+	placeholders := make([]string, len(jobs))
+	args := make([]interface{}, len(jobs))
+	for i, job := range jobs {
+		job.Tags = []string{}
+		byID[job.ID] = job
+		placeholders[i] = "?"
+		args[i] = job.ID
+	}
+
+	query := `
+  SELECT jt.job_application_id, t.name
+  FROM job_application_tags jt
+  JOIN tags t ON t.id = jt.tag_id
+  WHERE jt.job_application_id IN (` + strings.Join(placeholders, ", ") + `)
+  ORDER BY LOWER(t.name) ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int
+		var name string
+		if err := rows.Scan(&jobID, &name); err != nil {
+			return fmt.Errorf("failed to scan tag: %w", err)
+		}
+		byID[jobID].Tags = append(byID[jobID].Tags, name)
+	}
+
+	return rows.Err()
+}
+
+// setJobTags replaces a job application's tags, creating the user's tags that do not exist yet.
+// Tags are matched case-insensitively, and tags no application uses any more are removed.
+func setJobTags(ctx context.Context, tx *txn, userID, jobID int, tags []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_application_tags WHERE job_application_id = ?`, jobID); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+
+	for _, name := range models.NormalizeTags(tags) {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tags (user_id, name) VALUES (?, ?) ON CONFLICT (user_id, name) DO NOTHING`, userID, name); err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+  INSERT INTO job_application_tags (job_application_id, tag_id)
+  SELECT CAST(? AS INTEGER), id FROM tags WHERE user_id = ? AND name = ?
+  `, jobID, userID, name)
+		if err != nil {
+			return fmt.Errorf("failed to tag job application: %w", err)
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM job_application_tags)`)
+	if err != nil {
+		return fmt.Errorf("failed to remove unused tags: %w", err)
+	}
+
+	return nil
+}
+
+// tagFilter returns the SQL condition limiting job applications to those tagged with
+// all (or, unless matchAll, any) of the user's given tags, along with its arguments.
+// The tags must be normalized and non-empty.
+func tagFilter(userID int, tags []string, matchAll bool) (string, []interface{}) {
+	placeholders := make([]string, len(tags))
+	args := []interface{}{userID}
+	for i, tag := range tags {
+		placeholders[i] = "?"
+		args = append(args, tag)
+	}
+
+	condition := `id IN (
+    SELECT jt.job_application_id
+    FROM job_application_tags jt
+    JOIN tags t ON t.id = jt.tag_id
+    WHERE t.user_id = ? AND t.name IN (` + strings.Join(placeholders, ", ") + `)
+    GROUP BY jt.job_application_id`
+	if matchAll {
+		condition += `
+    HAVING COUNT(*) = ?`
+		args = append(args, len(tags))
+	}
+	condition += `
+  )`
+
+	return condition, args
+}
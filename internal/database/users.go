@@ -0,0 +1,274 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"hunter-seeker/internal/models"
+)
+
+// User errors
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrUsernameTaken = errors.New("another user already has that username")
+	ErrLastAdmin     = errors.New("there must always be at least one admin")
+)
+
+const userColumns = `id, username, password_hash, is_admin, created_at, updated_at`
+
+// scanUser reads a user selected with userColumns, followed by any extra columns
+func scanUser(row rowScanner, extra ...interface{}) (*models.User, error) {
+	user := &models.User{}
+	dest := []interface{}{&user.ID, &user.Username, &user.PasswordHash, &user.IsAdmin, &user.CreatedAt, &user.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUsers retrieves every user by username, with the number of applications each has
+func (db *DB) GetUsers(ctx context.Context) ([]*models.User, error) {
+	query := `
+  SELECT ` + userColumns + `,
+    (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = users.id)
+  FROM users
+  ORDER BY username ASC, id ASC
+  `
+
+	rows, err := db.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var count int
+		user, err := scanUser(rows, &count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		user.ApplicationCount = count
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// GetUser retrieves a user by ID
+func (db *DB) GetUser(ctx context.Context, id int) (*models.User, error) {
+	return db.getUser(ctx, `id = ?`, id)
+}
+
+// GetUserByUsername retrieves a user by username, ignoring case
+func (db *DB) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	return db.getUser(ctx, `username = ?`, username)
+}
+
+// DefaultUser returns the first admin, whose data is shown when no login is required
+func (db *DB) DefaultUser(ctx context.Context) (*models.User, error) {
+	return db.getUser(ctx, `is_admin ORDER BY id ASC LIMIT 1`)
+}
+
+func (db *DB) getUser(ctx context.Context, where string, args ...interface{}) (*models.User, error) {
+	user, err := scanUser(db.conn.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE `+where, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// AdminHasPassword reports whether any admin can log in with a password
+func (db *DB) AdminHasPassword(ctx context.Context) (bool, error) {
+	var exists bool
+	err := db.conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE is_admin AND password_hash != '')`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check admin passwords: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateUser adds a user with the given username, password hash and admin flag
+func (db *DB) CreateUser(ctx context.Context, user *models.User) error {
+	if err := db.checkUsername(ctx, user.Username, 0); err != nil {
+		return err
+	}
+
+	query := `
+  INSERT INTO users (username, password_hash, is_admin)
+  VALUES (?, ?, ?)
+  RETURNING id, created_at, updated_at
+  `
+
+	err := db.conn.QueryRowContext(ctx, query, user.Username, user.PasswordHash, user.IsAdmin).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUser saves a user's username and admin flag. The last admin cannot stop
+// being one, or ErrLastAdmin is returned.
+func (db *DB) UpdateUser(ctx context.Context, user *models.User) error {
+	if err := db.checkUsername(ctx, user.Username, user.ID); err != nil {
+		return err
+	}
+
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET username = ?, is_admin = ? WHERE id = ?`, user.Username, user.IsAdmin, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	if err := checkAdminRemains(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user update: %w", err)
+	}
+
+	return nil
+}
+
+// SetUserPassword saves the hash of a user's password, or "" to stop them logging in,
+// and ends their sessions
+func (db *DB) SetUserPassword(ctx context.Context, id int, hash string) error {
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ?`, hash, id)
+	if err != nil {
+		return fmt.Errorf("failed to save password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to end sessions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit password: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes a user along with all their data and attachments.
+// The last admin cannot be deleted, or ErrLastAdmin is returned.
+func (db *DB) DeleteUser(ctx context.Context, id int) error {
+	tx, err := db.conn.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+  SELECT a.stored_name
+  FROM attachments a
+  JOIN job_applications j ON j.id = a.job_application_id
+  WHERE j.user_id = ?
+  `, id)
+	if err != nil {
+		return fmt.Errorf("failed to query attachments: %w", err)
+	}
+
+	var storedNames []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		storedNames = append(storedNames, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read attachments: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	if err := checkAdminRemains(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user deletion: %w", err)
+	}
+
+	for _, name := range storedNames {
+		if err := db.removeAttachmentFile(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkAdminRemains returns ErrLastAdmin if a change inside tx left no admin
+func checkAdminRemains(ctx context.Context, tx *txn) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE is_admin)`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check admins: %w", err)
+	}
+	if !exists {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+func (db *DB) checkUsername(ctx context.Context, username string, id int) error {
+	var taken bool
+	err := db.conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE username = ? AND id != ?)`, username, id).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("failed to check username: %w", err)
+	}
+	if taken {
+		return ErrUsernameTaken
+	}
+	return nil
+}
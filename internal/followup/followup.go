@@ -0,0 +1,106 @@
+package followup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// Item is a job application that needs a nudge, with the reason it was flagged
+type Item struct {
+	Job    *models.JobApplication `json:"job"`
+	Reason string                 `json:"reason"`
+	// Due is when the follow-up became due
+	Due time.Time `json:"due"`
+	// Overdue is true once the follow-up is at least a day late
+	Overdue bool `json:"overdue"`
+}
+
+// NeedsAttention returns the applications that need following up as of now,
+// most overdue first. An application needs attention when its follow-up date
+// has arrived, or when it has had no update for longer than the rule for its
+// status allows. A follow-up date in the future snoozes the rules.
+func NeedsAttention(jobs []*models.JobApplication, rules []*models.FollowUpRule, now time.Time) []Item {
+	byStatus := rulesByStatus(rules)
+	today := Today(now)
+
+	var items []Item
+	for _, job := range jobs {
+		if job.NextActionDate != nil {
+			if job.NextActionDate.After(today) {
+				continue
+			}
+			items = append(items, Item{
+				Job:     job,
+				Reason:  "Follow-up date reached",
+				Due:     *job.NextActionDate,
+				Overdue: job.NextActionDate.Before(today),
+			})
+			continue
+		}
+
+		rule, ok := byStatus[job.Status]
+		if !ok || rule.FollowUpDays <= 0 {
+			continue
+		}
+
+		due := job.UpdatedAt.AddDate(0, 0, rule.FollowUpDays)
+		if due.After(now) {
+			continue
+		}
+		items = append(items, Item{
+			Job:     job,
+			Reason:  fmt.Sprintf("No update in %d days while %s", daysSince(job.UpdatedAt, now), job.Status),
+			Due:     due,
+			Overdue: now.Sub(due) >= 24*time.Hour,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Due.Before(items[j].Due)
+	})
+	return items
+}
+
+// Silent returns the applications that have gone without an update for longer
+// than their rule's NoResponseDays and should be moved to the noResponse status.
+// Applications with a follow-up date in the future are left alone.
+func Silent(jobs []*models.JobApplication, rules []*models.FollowUpRule, noResponse string, now time.Time) []*models.JobApplication {
+	byStatus := rulesByStatus(rules)
+	today := Today(now)
+
+	var silent []*models.JobApplication
+	for _, job := range jobs {
+		rule, ok := byStatus[job.Status]
+		if !ok || rule.NoResponseDays <= 0 || job.Status == noResponse {
+			continue
+		}
+		if job.NextActionDate != nil && job.NextActionDate.After(today) {
+			continue
+		}
+		if job.UpdatedAt.AddDate(0, 0, rule.NoResponseDays).After(now) {
+			continue
+		}
+		silent = append(silent, job)
+	}
+	return silent
+}
+
+// Today returns the calendar date of now as midnight UTC, the way dates are stored
+func Today(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func rulesByStatus(rules []*models.FollowUpRule) map[string]*models.FollowUpRule {
+	byStatus := make(map[string]*models.FollowUpRule, len(rules))
+	for _, rule := range rules {
+		byStatus[rule.Status] = rule
+	}
+	return byStatus
+}
+
+func daysSince(t, now time.Time) int {
+	return int(now.Sub(t).Hours() / 24)
+}
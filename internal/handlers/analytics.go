@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"hunter-seeker/internal/analytics"
+)
+
+// parseDateRange reads the optional ?from= and ?to= dates (YYYY-MM-DD)
+func parseDateRange(r *http.Request) (from, to time.Time, err error) {
+	if value := r.URL.Query().Get("from"); value != "" {
+		from, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q (expected YYYY-MM-DD)", value)
+		}
+	}
+
+	if value := r.URL.Query().Get("to"); value != "" {
+		to, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q (expected YYYY-MM-DD)", value)
+		}
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to date must not be before from date")
+	}
+
+	return from, to, nil
+}
+
+// buildAnalytics loads the user's applications in the date range and computes the report
+func (h *Handler) buildAnalytics(r *http.Request, from, to time.Time) (*analytics.Report, error) {
+	jobs, err := h.store(r).GetJobApplicationsWithHistory(r.Context(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := h.store(r).GetStatuses(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics.Compute(jobs, statuses, from, to), nil
+}
+
+// AnalyticsHandler renders the pipeline analytics page
+func (h *Handler) AnalyticsHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.buildAnalytics(r, from, to)
+	if err != nil {
+		log.Printf("Error computing analytics: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	maxWeekCount := 0
+	for _, week := range report.ApplicationsPerWeek {
+		if week.Count > maxWeekCount {
+			maxWeekCount = week.Count
+		}
+	}
+
+	data := struct {
+		Report       *analytics.Report
+		From         string
+		To           string
+		MaxWeekCount int
+	}{
+		Report:       report,
+		From:         r.URL.Query().Get("from"),
+		To:           r.URL.Query().Get("to"),
+		MaxWeekCount: maxWeekCount,
+	}
+
+	if err := h.render(w, r, "analytics.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// APIAnalyticsHandler returns the pipeline analytics as JSON
+func (h *Handler) APIAnalyticsHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_date_range", err.Error())
+		return
+	}
+
+	report, err := h.buildAnalytics(r, from, to)
+	if err != nil {
+		log.Printf("Error computing analytics: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to compute analytics")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
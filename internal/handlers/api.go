@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// maxAPIBodySize limits the size of JSON request bodies (1MB)
+const maxAPIBodySize = 1 << 20
+
+// apiError is the body returned by the JSON API when a request fails
+type apiError struct {
+	Error apiErrorDetail `json:"error"`
+}
+
+type apiErrorDetail struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Fields  []string `json:"fields,omitempty"`
+}
+
+// jobRequest is the JSON body accepted when creating or updating a job application.
+// Fields are pointers so PATCH requests can tell omitted fields from empty ones.
+type jobRequest struct {
+	DateApplied *string `json:"date_applied"`
+	JobTitle    *string `json:"job_title"`
+	Company     *string `json:"company"`
+	Status      *string `json:"status"`
+	JobURL      *string `json:"job_url"`
+	Notes       *string `json:"notes"`
+	// NextActionDate clears the follow-up date when set to an empty string
+	NextActionDate *string `json:"next_action_date"`
+	// Tags replaces all of the application's tags when present
+	Tags *[]string `json:"tags"`
+	// SalaryMin and SalaryMax clear the amount when set to null
+	SalaryMin      nullableInt `json:"salary_min"`
+	SalaryMax      nullableInt `json:"salary_max"`
+	SalaryCurrency *string     `json:"salary_currency"`
+	Equity         *string     `json:"equity"`
+	Location       *string     `json:"location"`
+	// WorkMode is remote, hybrid, onsite, or empty if unknown
+	WorkMode *string `json:"work_mode"`
+}
+
+// nullableInt is an optional JSON number that can also be set to null, so PATCH
+// requests can tell an omitted field from one being cleared
+type nullableInt struct {
+	Set   bool
+	Value *int
+}
+
+// UnmarshalJSON records that the field was present and reads its value
+func (n *nullableInt) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	n.Value = nil
+	if string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error encoding JSON: %v", err)
+	}
+}
+
+// writeAPIError writes a structured JSON error response
+func writeAPIError(w http.ResponseWriter, status int, code, message string, fields ...string) {
+	writeJSON(w, status, apiError{Error: apiErrorDetail{Code: code, Message: message, Fields: fields}})
+}
+
+// apiJobID parses the {id} route variable, writing an error response if it is invalid
+func apiJobID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	return apiRouteID(w, r, "id", "job")
+}
+
+// apiRouteID parses a numeric route variable, writing an error response if it is invalid
+func apiRouteID(w http.ResponseWriter, r *http.Request, name, resource string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil || id <= 0 {
+		writeAPIError(w, http.StatusBadRequest, "invalid_id", fmt.Sprintf("Invalid %s ID", resource))
+		return 0, false
+	}
+	return id, true
+}
+
+// decodeJobRequest reads a jobRequest from the request body, writing an error response on failure
+func decodeJobRequest(w http.ResponseWriter, r *http.Request) (*jobRequest, bool) {
+	var req jobRequest
+	if !decodeJSONBody(w, r, &req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+// decodeJSONBody strictly decodes the request body into v, writing an error response on failure
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_json", fmt.Sprintf("Invalid JSON body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+// apply copies the fields present in the request onto job.
+// It returns the name of the invalid field along with the error.
+func (req *jobRequest) apply(job *models.JobApplication) (string, error) {
+	if req.DateApplied != nil {
+		dateApplied, err := parseAPIDate("date_applied", *req.DateApplied)
+		if err != nil {
+			return "date_applied", err
+		}
+		job.DateApplied = dateApplied
+	}
+	if req.JobTitle != nil {
+		job.JobTitle = strings.TrimSpace(*req.JobTitle)
+	}
+	if req.Company != nil {
+		job.Company = strings.TrimSpace(*req.Company)
+	}
+	if req.Status != nil {
+		job.Status = strings.TrimSpace(*req.Status)
+	}
+	if req.JobURL != nil {
+		job.JobURL = strings.TrimSpace(*req.JobURL)
+	}
+	if req.Notes != nil {
+		job.Notes = *req.Notes
+	}
+	if req.NextActionDate != nil {
+		job.NextActionDate = nil
+		if strings.TrimSpace(*req.NextActionDate) != "" {
+			nextActionDate, err := parseAPIDate("next_action_date", *req.NextActionDate)
+			if err != nil {
+				return "next_action_date", err
+			}
+			job.NextActionDate = &nextActionDate
+		}
+	}
+	if req.Tags != nil {
+		job.Tags = models.NormalizeTags(*req.Tags)
+	}
+	if req.SalaryMin.Set {
+		job.SalaryMin = req.SalaryMin.Value
+	}
+	if req.SalaryMax.Set {
+		job.SalaryMax = req.SalaryMax.Value
+	}
+	if req.SalaryCurrency != nil {
+		job.SalaryCurrency = strings.ToUpper(strings.TrimSpace(*req.SalaryCurrency))
+	}
+	if req.Equity != nil {
+		job.Equity = strings.TrimSpace(*req.Equity)
+	}
+	if req.Location != nil {
+		job.Location = strings.TrimSpace(*req.Location)
+	}
+	if req.WorkMode != nil {
+		mode, ok := models.ParseWorkMode(*req.WorkMode)
+		if !ok {
+			return "work_mode", fmt.Errorf("work_mode must be one of %s", strings.Join(models.GetWorkModes(), ", "))
+		}
+		job.WorkMode = mode
+	}
+
+	return job.ValidateCompensation()
+}
+
+// parseAPIDate accepts either a plain date (YYYY-MM-DD) or a full RFC 3339 timestamp
+// for the named field
+func parseAPIDate(field, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		// Store only the calendar date, like dates entered through the forms
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q (expected YYYY-MM-DD)", field, value)
+}
+
+// applyDefaultStatus gives a job without a status the first status of the workflow,
+// writing an error response if it cannot be determined
+func (h *Handler) applyDefaultStatus(ctx context.Context, w http.ResponseWriter, job *models.JobApplication) bool {
+	if job.Status != "" {
+		return true
+	}
+
+	status, err := h.db.DefaultStatus(ctx)
+	if err != nil {
+		log.Printf("Error getting default status: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to determine the default status")
+		return false
+	}
+
+	job.Status = status
+	return true
+}
+
+// writeStatusError writes the API error for an unknown status or a disallowed
+// status change, reporting whether err was one of those
+func writeStatusError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, database.ErrInvalidStatus):
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_field", err.Error(), "status")
+	case errors.Is(err, database.ErrTransitionNotAllowed):
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_transition", err.Error(), "status")
+	default:
+		return false
+	}
+	return true
+}
+
+// missingJobFields returns the names of required fields that are empty
+func missingJobFields(job *models.JobApplication) []string {
+	var missing []string
+	if job.DateApplied.IsZero() {
+		missing = append(missing, "date_applied")
+	}
+	if job.JobTitle == "" {
+		missing = append(missing, "job_title")
+	}
+	if job.Company == "" {
+		missing = append(missing, "company")
+	}
+	return missing
+}
+
+// APIListJobsHandler returns all job applications as JSON, newest first, optionally
+// filtered by ?status= and by ?tag= (repeatable; ?match=any for any instead of all tags)
+func (h *Handler) APIListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	jobs, _, err := h.store(r).ListJobApplications(r.Context(), listFilter(r))
+	if err != nil {
+		log.Printf("Error getting job applications: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to list job applications")
+		return
+	}
+
+	if jobs == nil {
+		jobs = []*models.JobApplication{}
+	}
+
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+// APIGetJobHandler returns a single job application as JSON
+func (h *Handler) APIGetJobHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.store(r).GetJobApplication(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", id))
+			return
+		}
+		log.Printf("Error getting job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to get job application")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// APICreateJobHandler creates a job application from a JSON body
+func (h *Handler) APICreateJobHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeJobRequest(w, r)
+	if !ok {
+		return
+	}
+
+	job := &models.JobApplication{}
+	if field, err := req.apply(job); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_field", err.Error(), field)
+		return
+	}
+	if !h.applyDefaultStatus(r.Context(), w, job) {
+		return
+	}
+
+	if missing := missingJobFields(job); len(missing) > 0 {
+		writeAPIError(w, http.StatusUnprocessableEntity, "missing_fields", "Required fields are missing", missing...)
+		return
+	}
+
+	if err := h.store(r).CreateJobApplication(r.Context(), job); err != nil {
+		if writeStatusError(w, err) {
+			return
+		}
+		log.Printf("Error creating job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to create job application")
+		return
+	}
+
+	// Re-read so timestamps set by the database are included in the response
+	created, err := h.store(r).GetJobApplication(r.Context(), job.ID)
+	if err != nil {
+		log.Printf("Error getting created job application: %v", err)
+		created = job
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// APIUpdateJobHandler updates a job application from a JSON body.
+// PUT replaces the whole application; PATCH only changes the fields provided.
+func (h *Handler) APIUpdateJobHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := decodeJobRequest(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.store(r).GetJobApplication(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", id))
+			return
+		}
+		log.Printf("Error getting job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to get job application")
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		// A full replacement starts from an empty application
+		job = &models.JobApplication{ID: id}
+	}
+
+	if field, err := req.apply(job); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_field", err.Error(), field)
+		return
+	}
+	if !h.applyDefaultStatus(r.Context(), w, job) {
+		return
+	}
+
+	if missing := missingJobFields(job); len(missing) > 0 {
+		writeAPIError(w, http.StatusUnprocessableEntity, "missing_fields", "Required fields are missing", missing...)
+		return
+	}
+
+	if err := h.store(r).UpdateJobApplication(r.Context(), job); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", id))
+			return
+		}
+		if writeStatusError(w, err) {
+			return
+		}
+		log.Printf("Error updating job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to update job application")
+		return
+	}
+
+	updated, err := h.store(r).GetJobApplication(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting updated job application: %v", err)
+		updated = job
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// APIDeleteJobHandler deletes a job application
+func (h *Handler) APIDeleteJobHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store(r).DeleteJobApplication(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", id))
+			return
+		}
+		log.Printf("Error deleting job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to delete job application")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
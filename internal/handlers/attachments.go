@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// maxAttachmentSize is the largest file that can be uploaded (20 MB)
+const maxAttachmentSize = 20 << 20
+
+// UploadAttachmentHandler saves a file uploaded against a job application from the edit page
+func (h *Handler) UploadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxFormSize)
+	if err := r.ParseMultipartForm(maxAttachmentSize); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to read upload; files may be up to %d MB", maxAttachmentSize>>20), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Please choose a file to upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxAttachmentSize {
+		http.Error(w, fmt.Sprintf("Files may be up to %d MB", maxAttachmentSize>>20), http.StatusBadRequest)
+		return
+	}
+
+	kind := r.FormValue("kind")
+	if kind == "" {
+		kind = models.AttachmentOther
+	}
+	if !models.IsValidAttachmentKind(kind) {
+		http.Error(w, fmt.Sprintf("Kind must be one of %s", strings.Join(models.GetAttachmentKinds(), ", ")), http.StatusBadRequest)
+		return
+	}
+
+	attachment := &models.Attachment{
+		JobApplicationID: jobID,
+		Kind:             kind,
+		Filename:         filepath.Base(header.Filename),
+		ContentType:      attachmentContentType(header.Filename, header.Header.Get("Content-Type")),
+	}
+
+	if err := h.store(r).CreateAttachment(r.Context(), attachment, file); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			http.Error(w, "Job application not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error saving attachment: %v", err)
+		http.Error(w, "Failed to save attachment", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#attachments", jobID), http.StatusSeeOther)
+}
+
+// attachmentContentType picks the content type of an upload from its file extension,
+// falling back to the type the browser sent
+func attachmentContentType(filename, sent string) string {
+	if contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); contentType != "" {
+		return contentType
+	}
+	if _, _, err := mime.ParseMediaType(sent); err == nil {
+		return sent
+	}
+	return "application/octet-stream"
+}
+
+// DownloadAttachmentHandler sends an attachment's file with its original name
+func (h *Handler) DownloadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	attachment, err := h.store(r).GetAttachment(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrAttachmentNotFound) {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting attachment: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	file, err := h.store(r).OpenAttachment(attachment)
+	if err != nil {
+		log.Printf("Error opening attachment: %v", err)
+		http.Error(w, "Attachment file is missing", http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", attachment.CreatedAt, file)
+}
+
+// DeleteAttachmentHandler deletes an attachment from the edit page
+func (h *Handler) DeleteAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+	attachmentID, err := routeID(r, "attachmentID")
+	if err != nil {
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	attachment, err := h.store(r).GetAttachment(r.Context(), attachmentID)
+	if err == nil && attachment.JobApplicationID != jobID {
+		err = database.ErrAttachmentNotFound
+	}
+	if err == nil {
+		err = h.store(r).DeleteAttachment(r.Context(), attachmentID)
+	}
+	if err != nil {
+		if errors.Is(err, database.ErrAttachmentNotFound) {
+			http.Error(w, "Attachment not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error deleting attachment: %v", err)
+		http.Error(w, "Failed to delete attachment", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#attachments", jobID), http.StatusSeeOther)
+}
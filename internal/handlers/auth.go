@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hunter-seeker/internal/auth"
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// sessionCookieName is the cookie holding the login session token
+const sessionCookieName = "hunter_seeker_session"
+
+// defaultSessionTTL is how long a login lasts when AuthConfig does not say
+const defaultSessionTTL = 30 * 24 * time.Hour
+
+// AuthConfig configures the optional login. With the zero value no login is required
+// until an admin has a password, and everyone works with the first admin's data.
+type AuthConfig struct {
+	// Required asks for the admin's password to be chosen on the first visit if none is set
+	Required bool
+	// PasswordHash is the bcrypt hash of the first admin's password, such as one given
+	// in the environment. It takes precedence over a password chosen in the app.
+	PasswordHash string
+	// SecureCookies marks the session cookie Secure even on plain HTTP requests,
+	// for running behind a proxy that terminates TLS
+	SecureCookies bool
+	// SessionTTL is how long a login lasts
+	SessionTTL time.Duration
+}
+
+// authState is the login configuration along with whether an admin has chosen a password
+type authState struct {
+	mu               sync.RWMutex
+	config           AuthConfig
+	adminHasPassword bool
+}
+
+// userContextKey is the request context key of the user making the request
+type userContextKey struct{}
+
+// ConfigureAuth sets up the optional login, checking for admin passwords chosen in the app
+func (h *Handler) ConfigureAuth(config AuthConfig) error {
+	if config.PasswordHash != "" && !auth.IsPasswordHash(config.PasswordHash) {
+		return errors.New("password hash is not a bcrypt hash")
+	}
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = defaultSessionTTL
+	}
+
+	h.auth.mu.Lock()
+	h.auth.config = config
+	h.auth.mu.Unlock()
+
+	return h.reloadAuth(context.Background())
+}
+
+// reloadAuth checks again whether an admin has a password, after users change
+func (h *Handler) reloadAuth(ctx context.Context) error {
+	adminHasPassword, err := h.db.AdminHasPassword(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.auth.mu.Lock()
+	defer h.auth.mu.Unlock()
+	h.auth.adminHasPassword = adminHasPassword
+	return nil
+}
+
+// loginRequired reports whether pages need a login: when it is configured, or once an
+// admin has a password
+func (h *Handler) loginRequired() bool {
+	h.auth.mu.RLock()
+	defer h.auth.mu.RUnlock()
+	return h.auth.config.Required || h.auth.config.PasswordHash != "" || h.auth.adminHasPassword
+}
+
+// setupNeeded reports whether no admin can log in yet, so the login page asks for
+// the first admin's password instead
+func (h *Handler) setupNeeded() bool {
+	h.auth.mu.RLock()
+	defer h.auth.mu.RUnlock()
+	return h.auth.config.PasswordHash == "" && !h.auth.adminHasPassword
+}
+
+// passwordHash returns the hash to check a user's logins against, or "" if they have no
+// password. The configured password belongs to the first admin.
+func (h *Handler) passwordHash(ctx context.Context, user *models.User) (string, error) {
+	h.auth.mu.RLock()
+	configured := h.auth.config.PasswordHash
+	h.auth.mu.RUnlock()
+
+	if configured != "" {
+		admin, err := h.db.DefaultUser(ctx)
+		if err != nil {
+			return "", err
+		}
+		if admin.ID == user.ID {
+			return configured, nil
+		}
+	}
+	return user.PasswordHash, nil
+}
+
+// currentUser returns the user making a request, as set by RequireLogin
+func currentUser(r *http.Request) *models.User {
+	user, _ := r.Context().Value(userContextKey{}).(*models.User)
+	return user
+}
+
+// isAdmin reports whether the user making a request is an admin. Requests that did
+// not pass through RequireLogin act as the first admin.
+func isAdmin(r *http.Request) bool {
+	user := currentUser(r)
+	return user == nil || user.IsAdmin
+}
+
+// requireAdmin writes a 403 and returns false unless the request is made by an admin
+func requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if isAdmin(r) {
+		return true
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		writeAPIError(w, http.StatusForbidden, "forbidden", "Only admins can do this")
+	} else {
+		http.Error(w, "Forbidden: only admins can do this", http.StatusForbidden)
+	}
+	return false
+}
+
+// store returns the database as seen by the user making a request. Requests that did
+// not pass through RequireLogin see the first admin's data.
+func (h *Handler) store(r *http.Request) database.Store {
+	if user := currentUser(r); user != nil {
+		return h.db.ForUser(user.ID)
+	}
+	return h.db
+}
+
+// publicRequest reports whether a request is served without a login. Calendar apps
+// cannot log in, so the calendar feed is too when it is given a token, which
+// CalendarFeedHandler checks instead.
+func publicRequest(r *http.Request) bool {
+	path := r.URL.Path
+	if path == "/calendar.ics" && r.URL.Query().Get("token") != "" {
+		return true
+	}
+	return path == "/health" || path == "/login" || strings.HasPrefix(path, "/static/")
+}
+
+// RequireLogin wraps the router so that, when a login is required, every route except
+// the health check, static files, the login page and the calendar feed opened by its
+// token needs a valid session.
+// Web pages redirect to the login page; API requests get a 401 JSON error.
+// Requests are made by the logged in user, or the first admin when no login is required.
+func (h *Handler) RequireLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var user *models.User
+		var err error
+		if h.loginRequired() {
+			user, err = h.sessionUser(r)
+		} else {
+			user, err = h.db.DefaultUser(r.Context())
+		}
+		if err != nil {
+			log.Printf("Error checking session: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if user != nil {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeAPIError(w, http.StatusUnauthorized, "unauthorized", "Log in to use the API")
+			return
+		}
+
+		login := "/login"
+		if r.Method == http.MethodGet && r.URL.RequestURI() != "/" {
+			login += "?" + url.Values{"next": {r.URL.RequestURI()}}.Encode()
+		}
+		http.Redirect(w, r, login, http.StatusSeeOther)
+	})
+}
+
+// sessionUser returns the user whose unexpired session cookie the request carries, or nil
+func (h *Handler) sessionUser(r *http.Request) (*models.User, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil, nil
+	}
+	return h.db.SessionUser(r.Context(), auth.HashToken(cookie.Value), time.Now())
+}
+
+// loginPage is the data of login.html
+type loginPage struct {
+	// Setup asks for the first admin's username and a new password instead of logging in
+	Setup    bool
+	Username string
+	Next     string
+	Error    string
+}
+
+// renderLogin renders the login page with the given status code
+func (h *Handler) renderLogin(w http.ResponseWriter, r *http.Request, status int, page loginPage) {
+	w.WriteHeader(status)
+	if err := h.render(w, r, "login.html", page); err != nil {
+		log.Printf("Error executing template: %v", err)
+	}
+}
+
+// LoginPageHandler renders the login page, or the form to set up the admin on first run
+func (h *Handler) LoginPageHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.loginRequired() {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	page := loginPage{Setup: h.setupNeeded(), Next: safeNext(r.URL.Query().Get("next"))}
+	if page.Setup {
+		admin, err := h.db.DefaultUser(r.Context())
+		if err != nil {
+			log.Printf("Error getting admin: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		page.Username = admin.Username
+	}
+
+	h.renderLogin(w, r, http.StatusOK, page)
+}
+
+// LoginHandler checks a username and password, or sets up the first admin, and starts a session
+func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.loginRequired() {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	page := loginPage{
+		Setup:    h.setupNeeded(),
+		Username: strings.TrimSpace(r.FormValue("username")),
+		Next:     safeNext(r.FormValue("next")),
+	}
+	password := r.FormValue("password")
+
+	var user *models.User
+	if page.Setup {
+		if err := models.ValidateUsername(page.Username); err != nil {
+			page.Error = err.Error()
+		} else if len(password) < auth.MinPasswordLength {
+			page.Error = fmt.Sprintf("Choose a password of at least %d characters", auth.MinPasswordLength)
+		} else if password != r.FormValue("confirm") {
+			page.Error = "The passwords do not match"
+		}
+		if page.Error != "" {
+			h.renderLogin(w, r, http.StatusBadRequest, page)
+			return
+		}
+
+		admin, err := h.setUpAdmin(r.Context(), page.Username, password)
+		if errors.Is(err, database.ErrUsernameTaken) {
+			page.Error = fmt.Sprintf("The username %q is taken", page.Username)
+			h.renderLogin(w, r, http.StatusBadRequest, page)
+			return
+		}
+		if err != nil {
+			log.Printf("Error setting up admin: %v", err)
+			http.Error(w, "Failed to save password", http.StatusInternalServerError)
+			return
+		}
+		user = admin
+	} else {
+		found, err := h.db.GetUserByUsername(r.Context(), page.Username)
+		if err != nil && !errors.Is(err, database.ErrUserNotFound) {
+			log.Printf("Error getting user: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		hash := ""
+		if found != nil {
+			if hash, err = h.passwordHash(r.Context(), found); err != nil {
+				log.Printf("Error getting password: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+		if !auth.CheckPassword(hash, password) {
+			page.Error = "Incorrect username or password"
+			h.renderLogin(w, r, http.StatusUnauthorized, page)
+			return
+		}
+		user = found
+	}
+
+	if err := h.startSession(w, r, user.ID); err != nil {
+		log.Printf("Error starting session: %v", err)
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, page.Next, http.StatusSeeOther)
+}
+
+// LogoutHandler ends the current session
+func (h *Handler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if err := h.db.DeleteSession(r.Context(), auth.HashToken(cookie.Value)); err != nil {
+			log.Printf("Error ending session: %v", err)
+		}
+	}
+
+	http.SetCookie(w, h.sessionCookie(r, sessionCookieName, "", -1))
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// setUpAdmin gives the first admin the username and password chosen on first run
+func (h *Handler) setUpAdmin(ctx context.Context, username, password string) (*models.User, error) {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := h.db.DefaultUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	admin.Username = username
+	if err := h.db.UpdateUser(ctx, admin); err != nil {
+		return nil, err
+	}
+	if err := h.db.SetUserPassword(ctx, admin.ID, hash); err != nil {
+		return nil, err
+	}
+
+	return admin, h.reloadAuth(ctx)
+}
+
+// startSession records a new session for a user and sets its cookie
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, userID int) error {
+	token, err := auth.NewSessionToken()
+	if err != nil {
+		return err
+	}
+
+	h.auth.mu.RLock()
+	ttl := h.auth.config.SessionTTL
+	h.auth.mu.RUnlock()
+
+	now := time.Now()
+	if err := h.db.DeleteExpiredSessions(r.Context(), now); err != nil {
+		log.Printf("Error deleting expired sessions: %v", err)
+	}
+	if err := h.db.CreateSession(r.Context(), auth.HashToken(token), userID, now.Add(ttl)); err != nil {
+		return err
+	}
+
+	http.SetCookie(w, h.sessionCookie(r, sessionCookieName, token, int(ttl.Seconds())))
+	return nil
+}
+
+// sessionCookie builds a cookie lasting maxAge seconds (or the browser session if 0).
+// It is hidden from scripts, not sent with cross-site requests, and Secure on HTTPS.
+func (h *Handler) sessionCookie(r *http.Request, name, token string, maxAge int) *http.Cookie {
+	h.auth.mu.RLock()
+	secure := h.auth.config.SecureCookies || r.TLS != nil
+	h.auth.mu.RUnlock()
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// safeNext returns where to go after logging in: a path on this site, or the dashboard
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sort"
+
+	"hunter-seeker/internal/models"
+)
+
+// boardColumn is a status column on the pipeline board
+type boardColumn struct {
+	Status string
+	Color  string
+	Jobs   []*models.JobApplication
+}
+
+// boardColumns groups jobs into one column per configured status, in workflow order.
+// Statuses that are no longer configured get their own columns at the end.
+func boardColumns(statuses []*models.Status, jobs []*models.JobApplication) []boardColumn {
+	columns := make([]boardColumn, len(statuses))
+	index := make(map[string]int)
+	for i, status := range statuses {
+		columns[i].Status = status.Name
+		columns[i].Color = status.Color
+		index[status.Name] = i
+	}
+
+	for _, job := range jobs {
+		i, ok := index[job.Status]
+		if !ok {
+			i = len(columns)
+			index[job.Status] = i
+			columns = append(columns, boardColumn{Status: job.Status})
+		}
+		columns[i].Jobs = append(columns[i].Jobs, job)
+	}
+
+	extra := columns[len(statuses):]
+	sort.Slice(extra, func(i, j int) bool {
+		return extra[i].Status < extra[j].Status
+	})
+
+	return columns
+}
+
+// BoardHandler renders the kanban board with applications grouped by status
+func (h *Handler) BoardHandler(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.store(r).GetAllJobApplications(r.Context())
+	if err != nil {
+		log.Printf("Error getting job applications: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := struct {
+		Columns []boardColumn
+	}{
+		Columns: boardColumns(h.workflow(r.Context()), jobs),
+	}
+
+	if err := h.render(w, r, "board.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"hunter-seeker/internal/calendar"
+	"hunter-seeker/internal/database"
+)
+
+// requestBaseURL returns the scheme and host the request was made to, used to
+// link calendar events back to the server
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// writeCalendar sends cal as an iCalendar response. A filename makes it a download.
+func writeCalendar(w http.ResponseWriter, cal *calendar.Calendar, filename string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if filename != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+
+	if err := cal.Write(w, time.Now()); err != nil {
+		log.Printf("Error writing calendar: %v", err)
+	}
+}
+
+// CalendarFeedHandler serves every interview and follow-up date as a subscribable iCalendar
+// feed. Calendar apps open it with the user's token, which RequireLogin lets through.
+func (h *Handler) CalendarFeedHandler(w http.ResponseWriter, r *http.Request) {
+	store := h.store(r)
+	if token := r.URL.Query().Get("token"); token != "" {
+		user, err := h.db.CalendarTokenUser(r.Context(), token)
+		if err != nil {
+			log.Printf("Error checking calendar token: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if user == nil {
+			http.Error(w, "Calendar feed not found", http.StatusNotFound)
+			return
+		}
+		store = h.db.ForUser(user.ID)
+	}
+
+	interviews, err := store.GetAllInterviews(r.Context())
+	if err != nil {
+		log.Printf("Error getting interviews: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	jobs, err := store.GetJobApplicationsWithFollowUp(r.Context())
+	if err != nil {
+		log.Printf("Error getting follow-ups: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	baseURL := requestBaseURL(r)
+	cal := &calendar.Calendar{Name: "Hunter-Seeker"}
+	for _, interview := range interviews {
+		cal.Events = append(cal.Events, calendar.InterviewEvent(interview, baseURL))
+	}
+	for _, job := range jobs {
+		if event, ok := calendar.FollowUpEvent(job, baseURL); ok {
+			cal.Events = append(cal.Events, event)
+		}
+	}
+
+	writeCalendar(w, cal, "")
+}
+
+// InterviewICSHandler downloads a single interview as an .ics file
+func (h *Handler) InterviewICSHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid interview ID", http.StatusBadRequest)
+		return
+	}
+
+	interview, err := h.store(r).GetInterview(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrInterviewNotFound) {
+			http.Error(w, "Interview not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting interview: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	interview.Job, err = h.store(r).GetJobApplication(r.Context(), interview.JobApplicationID)
+	if err != nil {
+		log.Printf("Error getting job application: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	cal := &calendar.Calendar{Events: []calendar.Event{calendar.InterviewEvent(interview, requestBaseURL(r))}}
+	writeCalendar(w, cal, fmt.Sprintf("interview-%d.ics", interview.ID))
+}
+
+// FollowUpICSHandler downloads a job application's follow-up date as an .ics file
+func (h *Handler) FollowUpICSHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.store(r).GetJobApplication(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			http.Error(w, "Job application not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting job application: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	event, ok := calendar.FollowUpEvent(job, requestBaseURL(r))
+	if !ok {
+		http.Error(w, "Job application has no follow-up date", http.StatusNotFound)
+		return
+	}
+
+	cal := &calendar.Calendar{Events: []calendar.Event{event}}
+	writeCalendar(w, cal, fmt.Sprintf("follow-up-%d.ics", job.ID))
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// companyRequest is the JSON body accepted when updating a company.
+// Fields are pointers so PATCH requests can tell omitted fields from empty ones.
+type companyRequest struct {
+	Name     *string `json:"name"`
+	Size     *string `json:"size"`
+	Industry *string `json:"industry"`
+	Website  *string `json:"website"`
+	Notes    *string `json:"notes"`
+}
+
+// apply copies the fields present in the request onto company
+func (req *companyRequest) apply(company *models.Company) {
+	if req.Name != nil {
+		company.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Size != nil {
+		company.Size = strings.TrimSpace(*req.Size)
+	}
+	if req.Industry != nil {
+		company.Industry = strings.TrimSpace(*req.Industry)
+	}
+	if req.Website != nil {
+		company.Website = strings.TrimSpace(*req.Website)
+	}
+	if req.Notes != nil {
+		company.Notes = *req.Notes
+	}
+}
+
+// companyOutcome is the number of applications at a company in one status
+type companyOutcome struct {
+	Status string
+	Count  int
+}
+
+// companyOutcomes counts a company's applications by status, in workflow order
+func companyOutcomes(statuses []*models.Status, jobs []*models.JobApplication) []companyOutcome {
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		counts[job.Status]++
+	}
+
+	var outcomes []companyOutcome
+	for _, column := range boardColumns(statuses, jobs) {
+		if n := counts[column.Status]; n > 0 {
+			outcomes = append(outcomes, companyOutcome{Status: column.Status, Count: n})
+		}
+	}
+	return outcomes
+}
+
+// CompaniesHandler renders the list of companies with their application counts
+func (h *Handler) CompaniesHandler(w http.ResponseWriter, r *http.Request) {
+	companies, err := h.store(r).GetAllCompanies(r.Context())
+	if err != nil {
+		log.Printf("Error getting companies: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := struct {
+		Companies []*models.Company
+	}{
+		Companies: companies,
+	}
+
+	if err := h.render(w, r, "companies.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// CompanyHandler renders a company with its details, aliases and every application there
+func (h *Handler) CompanyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		return
+	}
+
+	company, err := h.store(r).GetCompany(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting company: %v", err)
+		http.Error(w, "Company not found", http.StatusNotFound)
+		return
+	}
+
+	var statusMessage, statusType string
+	switch r.URL.Query().Get("error") {
+	case "name_taken":
+		statusMessage, statusType = "Another company already uses that name. Add it as an alias to merge the two.", "error"
+	case "invalid_name":
+		statusMessage, statusType = "Names and aliases must contain letters or numbers.", "error"
+	}
+	if r.URL.Query().Get("success") == "updated" {
+		statusMessage, statusType = "Company updated", "success"
+	}
+
+	data := struct {
+		Company       *models.Company
+		Outcomes      []companyOutcome
+		StatusMessage string
+		StatusType    string
+	}{
+		Company:       company,
+		Outcomes:      companyOutcomes(h.workflow(r.Context()), company.Applications),
+		StatusMessage: statusMessage,
+		StatusType:    statusType,
+	}
+
+	if err := h.render(w, r, "company.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// UpdateCompanyHandler saves a company's name and details
+func (h *Handler) UpdateCompanyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	company := &models.Company{
+		ID:       id,
+		Name:     strings.TrimSpace(r.FormValue("name")),
+		Size:     strings.TrimSpace(r.FormValue("size")),
+		Industry: strings.TrimSpace(r.FormValue("industry")),
+		Website:  strings.TrimSpace(r.FormValue("website")),
+		Notes:    r.FormValue("notes"),
+	}
+
+	if err := h.store(r).UpdateCompany(r.Context(), company); err != nil {
+		h.redirectCompanyError(w, r, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/companies/%d?success=updated", id), http.StatusSeeOther)
+}
+
+// AddCompanyAliasHandler adds another name for a company, merging any company that already uses it
+func (h *Handler) AddCompanyAliasHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).AddCompanyAlias(r.Context(), id, r.FormValue("alias")); err != nil {
+		h.redirectCompanyError(w, r, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/companies/%d?success=updated", id), http.StatusSeeOther)
+}
+
+// RemoveCompanyAliasHandler deletes one of a company's aliases
+func (h *Handler) RemoveCompanyAliasHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		return
+	}
+	aliasID, err := routeID(r, "aliasID")
+	if err != nil {
+		http.Error(w, "Invalid alias ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).RemoveCompanyAlias(r.Context(), id, aliasID); err != nil {
+		h.redirectCompanyError(w, r, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/companies/%d?success=updated", id), http.StatusSeeOther)
+}
+
+// redirectCompanyError reports a failed company change back on the company page
+func (h *Handler) redirectCompanyError(w http.ResponseWriter, r *http.Request, id int, err error) {
+	switch {
+	case errors.Is(err, database.ErrCompanyNotFound):
+		http.Error(w, "Company not found", http.StatusNotFound)
+	case errors.Is(err, database.ErrCompanyNameTaken):
+		http.Redirect(w, r, fmt.Sprintf("/companies/%d?error=name_taken", id), http.StatusSeeOther)
+	case errors.Is(err, database.ErrInvalidAlias):
+		http.Redirect(w, r, fmt.Sprintf("/companies/%d?error=invalid_name", id), http.StatusSeeOther)
+	default:
+		log.Printf("Error updating company: %v", err)
+		http.Error(w, "Failed to update company", http.StatusInternalServerError)
+	}
+}
+
+// APIListCompaniesHandler returns companies as JSON. With ?name= it returns only
+// the company that name would be linked to, which is empty if there is none yet.
+func (h *Handler) APIListCompaniesHandler(w http.ResponseWriter, r *http.Request) {
+	var companies []*models.Company
+	var err error
+
+	if name := r.URL.Query().Get("name"); name != "" {
+		var company *models.Company
+		company, err = h.store(r).FindCompanyByName(r.Context(), name)
+		if err == nil {
+			company.Applications = nil
+			company.Aliases = nil
+			companies = append(companies, company)
+		} else if errors.Is(err, database.ErrCompanyNotFound) {
+			err = nil
+		}
+	} else {
+		companies, err = h.store(r).GetAllCompanies(r.Context())
+	}
+
+	if err != nil {
+		log.Printf("Error getting companies: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to list companies")
+		return
+	}
+
+	if companies == nil {
+		companies = []*models.Company{}
+	}
+
+	writeJSON(w, http.StatusOK, companies)
+}
+
+// APIGetCompanyHandler returns a company with its aliases and applications as JSON
+func (h *Handler) APIGetCompanyHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "company")
+	if !ok {
+		return
+	}
+
+	company, err := h.store(r).GetCompany(r.Context(), id)
+	if err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, company)
+}
+
+// APIUpdateCompanyHandler replaces (PUT) or partially updates (PATCH) a company's details
+func (h *Handler) APIUpdateCompanyHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "company")
+	if !ok {
+		return
+	}
+
+	var req companyRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	company, err := h.store(r).GetCompany(r.Context(), id)
+	if err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		company = &models.Company{ID: id}
+	}
+	req.apply(company)
+
+	if company.Name == "" {
+		writeAPIError(w, http.StatusUnprocessableEntity, "missing_fields", "Required fields are missing", "name")
+		return
+	}
+
+	if err := h.store(r).UpdateCompany(r.Context(), company); err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	updated, err := h.store(r).GetCompany(r.Context(), id)
+	if err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// APIAddCompanyAliasHandler adds an alias to a company from a {"alias": "..."} body
+func (h *Handler) APIAddCompanyAliasHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "company")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Alias string `json:"alias"`
+	}
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := h.store(r).AddCompanyAlias(r.Context(), id, req.Alias); err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	company, err := h.store(r).GetCompany(r.Context(), id)
+	if err != nil {
+		writeCompanyError(w, err, id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, company)
+}
+
+// writeCompanyError maps a company database error to an API error response
+func writeCompanyError(w http.ResponseWriter, err error, id int) {
+	switch {
+	case errors.Is(err, database.ErrCompanyNotFound):
+		writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Company with ID %d not found", id))
+	case errors.Is(err, database.ErrCompanyNameTaken):
+		writeAPIError(w, http.StatusConflict, "name_taken", err.Error(), "name")
+	case errors.Is(err, database.ErrInvalidAlias):
+		writeAPIError(w, http.StatusBadRequest, "invalid_field", err.Error())
+	default:
+		log.Printf("Error accessing company: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to access company")
+	}
+}
@@ -0,0 +1,496 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// contactRequest is the JSON body accepted when creating or updating a contact.
+// Fields are pointers so PATCH requests can tell omitted fields from empty ones.
+type contactRequest struct {
+	Name        *string `json:"name"`
+	Role        *string `json:"role"`
+	Email       *string `json:"email"`
+	Phone       *string `json:"phone"`
+	LinkedInURL *string `json:"linkedin_url"`
+	Notes       *string `json:"notes"`
+}
+
+// apply copies the fields present in the request onto contact
+func (req *contactRequest) apply(contact *models.Contact) {
+	if req.Name != nil {
+		contact.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Role != nil {
+		contact.Role = strings.TrimSpace(*req.Role)
+	}
+	if req.Email != nil {
+		contact.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.Phone != nil {
+		contact.Phone = strings.TrimSpace(*req.Phone)
+	}
+	if req.LinkedInURL != nil {
+		contact.LinkedInURL = strings.TrimSpace(*req.LinkedInURL)
+	}
+	if req.Notes != nil {
+		contact.Notes = *req.Notes
+	}
+}
+
+// contactFromForm reads contact fields from a submitted form
+func contactFromForm(r *http.Request) *models.Contact {
+	return &models.Contact{
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		Role:        strings.TrimSpace(r.FormValue("role")),
+		Email:       strings.TrimSpace(r.FormValue("email")),
+		Phone:       strings.TrimSpace(r.FormValue("phone")),
+		LinkedInURL: strings.TrimSpace(r.FormValue("linkedin_url")),
+		Notes:       r.FormValue("notes"),
+	}
+}
+
+// routeID parses a numeric route variable for the web pages
+func routeID(r *http.Request, name string) (int, error) {
+	return strconv.Atoi(mux.Vars(r)[name])
+}
+
+// ContactsHandler renders the list of contacts
+func (h *Handler) ContactsHandler(w http.ResponseWriter, r *http.Request) {
+	contacts, err := h.store(r).GetAllContacts(r.Context())
+	if err != nil {
+		log.Printf("Error getting contacts: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var statusMessage string
+	if r.URL.Query().Get("success") == "deleted" {
+		statusMessage = "Contact deleted successfully"
+	}
+
+	data := struct {
+		Contacts      []*models.Contact
+		StatusMessage string
+	}{
+		Contacts:      contacts,
+		StatusMessage: statusMessage,
+	}
+
+	if err := h.render(w, r, "contacts.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// NewContactHandler renders the form for adding a contact
+func (h *Handler) NewContactHandler(w http.ResponseWriter, r *http.Request) {
+	h.renderContactForm(w, r, &models.Contact{})
+}
+
+// renderContactForm renders contact_form.html for a new (zero ID) or existing contact
+func (h *Handler) renderContactForm(w http.ResponseWriter, r *http.Request, contact *models.Contact) {
+	data := struct {
+		Contact *models.Contact
+	}{
+		Contact: contact,
+	}
+
+	if err := h.render(w, r, "contact_form.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// CreateContactHandler creates a new contact
+func (h *Handler) CreateContactHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	contact := contactFromForm(r)
+	if contact.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).CreateContact(r.Context(), contact); err != nil {
+		log.Printf("Error creating contact: %v", err)
+		http.Error(w, "Failed to create contact", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/contacts/%d", contact.ID), http.StatusSeeOther)
+}
+
+// ContactHandler renders a contact with the applications they are linked to
+func (h *Handler) ContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.store(r).GetContact(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting contact: %v", err)
+		http.Error(w, "Contact not found", http.StatusNotFound)
+		return
+	}
+
+	data := struct {
+		Contact *models.Contact
+	}{
+		Contact: contact,
+	}
+
+	if err := h.render(w, r, "contact.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// EditContactHandler renders the form for editing a contact
+func (h *Handler) EditContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.store(r).GetContact(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting contact: %v", err)
+		http.Error(w, "Contact not found", http.StatusNotFound)
+		return
+	}
+
+	h.renderContactForm(w, r, contact)
+}
+
+// UpdateContactHandler updates an existing contact
+func (h *Handler) UpdateContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	contact := contactFromForm(r)
+	contact.ID = id
+	if contact.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).UpdateContact(r.Context(), contact); err != nil {
+		if errors.Is(err, database.ErrContactNotFound) {
+			http.Error(w, "Contact not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error updating contact: %v", err)
+		http.Error(w, "Failed to update contact", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/contacts/%d", id), http.StatusSeeOther)
+}
+
+// DeleteContactHandler deletes a contact and unlinks it from its applications
+func (h *Handler) DeleteContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).DeleteContact(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrContactNotFound) {
+			http.Error(w, "Contact not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error deleting contact: %v", err)
+		http.Error(w, "Failed to delete contact", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/contacts?success=deleted", http.StatusSeeOther)
+}
+
+// LinkJobContactHandler links a contact to a job application from the edit page.
+// The form either picks an existing contact_id or describes a new contact to create.
+func (h *Handler) LinkJobContactHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	var contactID int
+	if value := r.FormValue("contact_id"); value != "" {
+		contactID, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+			return
+		}
+	} else {
+		contact := contactFromForm(r)
+		if contact.Name == "" {
+			http.Error(w, "Name is required", http.StatusBadRequest)
+			return
+		}
+		if _, err := h.store(r).GetJobApplication(r.Context(), jobID); err != nil {
+			http.Error(w, "Job application not found", http.StatusNotFound)
+			return
+		}
+		if err := h.store(r).CreateContact(r.Context(), contact); err != nil {
+			log.Printf("Error creating contact: %v", err)
+			http.Error(w, "Failed to create contact", http.StatusInternalServerError)
+			return
+		}
+		contactID = contact.ID
+	}
+
+	if err := h.store(r).LinkContact(r.Context(), jobID, contactID); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) || errors.Is(err, database.ErrContactNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Printf("Error linking contact: %v", err)
+		http.Error(w, "Failed to link contact", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#contacts", jobID), http.StatusSeeOther)
+}
+
+// UnlinkJobContactHandler removes a contact from a job application without deleting it
+func (h *Handler) UnlinkJobContactHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+	contactID, err := routeID(r, "contactID")
+	if err != nil {
+		http.Error(w, "Invalid contact ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store(r).UnlinkContact(r.Context(), jobID, contactID); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) || errors.Is(err, database.ErrContactNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Printf("Error unlinking contact: %v", err)
+		http.Error(w, "Failed to unlink contact", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#contacts", jobID), http.StatusSeeOther)
+}
+
+// APIListContactsHandler returns all contacts as JSON
+func (h *Handler) APIListContactsHandler(w http.ResponseWriter, r *http.Request) {
+	contacts, err := h.store(r).GetAllContacts(r.Context())
+	if err != nil {
+		log.Printf("Error getting contacts: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to list contacts")
+		return
+	}
+
+	if contacts == nil {
+		contacts = []*models.Contact{}
+	}
+
+	writeJSON(w, http.StatusOK, contacts)
+}
+
+// APIGetContactHandler returns a single contact, with its linked applications, as JSON
+func (h *Handler) APIGetContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "contact")
+	if !ok {
+		return
+	}
+
+	contact, err := h.store(r).GetContact(r.Context(), id)
+	if err != nil {
+		writeContactLookupError(w, err, id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, contact)
+}
+
+// APICreateContactHandler creates a contact from a JSON body
+func (h *Handler) APICreateContactHandler(w http.ResponseWriter, r *http.Request) {
+	var req contactRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	contact := &models.Contact{}
+	req.apply(contact)
+	if contact.Name == "" {
+		writeAPIError(w, http.StatusUnprocessableEntity, "missing_fields", "Required fields are missing", "name")
+		return
+	}
+
+	if err := h.store(r).CreateContact(r.Context(), contact); err != nil {
+		log.Printf("Error creating contact: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to create contact")
+		return
+	}
+
+	created, err := h.store(r).GetContact(r.Context(), contact.ID)
+	if err != nil {
+		log.Printf("Error getting created contact: %v", err)
+		created = contact
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/contacts/%d", contact.ID))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// APIUpdateContactHandler replaces (PUT) or partially updates (PATCH) a contact
+func (h *Handler) APIUpdateContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "contact")
+	if !ok {
+		return
+	}
+
+	var req contactRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	contact, err := h.store(r).GetContact(r.Context(), id)
+	if err != nil {
+		writeContactLookupError(w, err, id)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		contact = &models.Contact{ID: id}
+	}
+	req.apply(contact)
+
+	if contact.Name == "" {
+		writeAPIError(w, http.StatusUnprocessableEntity, "missing_fields", "Required fields are missing", "name")
+		return
+	}
+
+	if err := h.store(r).UpdateContact(r.Context(), contact); err != nil {
+		writeContactLookupError(w, err, id)
+		return
+	}
+
+	updated, err := h.store(r).GetContact(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting updated contact: %v", err)
+		updated = contact
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// APIDeleteContactHandler deletes a contact
+func (h *Handler) APIDeleteContactHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "contact")
+	if !ok {
+		return
+	}
+
+	if err := h.store(r).DeleteContact(r.Context(), id); err != nil {
+		writeContactLookupError(w, err, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIListJobContactsHandler returns the contacts linked to a job application
+func (h *Handler) APIListJobContactsHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.store(r).GetJobApplication(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", jobID))
+			return
+		}
+		log.Printf("Error getting job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to get job application")
+		return
+	}
+
+	contacts := job.Contacts
+	if contacts == nil {
+		contacts = []*models.Contact{}
+	}
+
+	writeJSON(w, http.StatusOK, contacts)
+}
+
+// APILinkJobContactHandler links (PUT) or unlinks (DELETE) a contact and a job application
+func (h *Handler) APILinkJobContactHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+	contactID, ok := apiRouteID(w, r, "contactID", "contact")
+	if !ok {
+		return
+	}
+
+	var err error
+	if r.Method == http.MethodDelete {
+		err = h.store(r).UnlinkContact(r.Context(), jobID, contactID)
+	} else {
+		err = h.store(r).LinkContact(r.Context(), jobID, contactID)
+	}
+
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", jobID))
+			return
+		}
+		writeContactLookupError(w, err, contactID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeContactLookupError maps a contact database error to an API error response
+func writeContactLookupError(w http.ResponseWriter, err error, id int) {
+	if errors.Is(err, database.ErrContactNotFound) {
+		writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Contact with ID %d not found", id))
+		return
+	}
+	log.Printf("Error accessing contact: %v", err)
+	writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to access contact")
+}
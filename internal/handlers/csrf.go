@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"hunter-seeker/internal/auth"
+	"hunter-seeker/internal/models"
+)
+
+// csrfCookieName is the cookie holding the browser session's CSRF token
+const csrfCookieName = "hunter_seeker_csrf"
+
+// csrfFieldName is the hidden form field, and csrfHeaderName the request header,
+// that must repeat the token
+const (
+	csrfFieldName  = "csrf_token"
+	csrfHeaderName = "X-CSRF-Token"
+)
+
+// maxFormSize is the largest form body accepted, enough for an attachment upload.
+// The limit applies before the token is read, since reading it parses the whole form.
+const maxFormSize = maxAttachmentSize + 1<<20
+
+// csrfContextKey is the request context key of the CSRF token
+type csrfContextKey struct{}
+
+// CSRFProtect rejects state-changing requests that did not come from the app's own pages.
+// Each browser session gets a random token in a cookie; forms repeat it in a hidden field
+// (see csrfField), which another site cannot read, and a POST, PUT, PATCH or DELETE whose
+// field or X-CSRF-Token header does not match gets a 403.
+// API requests carry no token: a JSON body cannot be sent cross-site without a CORS
+// preflight, which the server never allows, so API POSTs must be application/json instead.
+func (h *Handler) CSRFProtect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			if r.Method == http.MethodPost && !isJSONRequest(r) {
+				writeAPIError(w, http.StatusForbidden, "csrf_failed", "API POST requests must be sent as application/json")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := ""
+		if cookie, err := r.Cookie(csrfCookieName); err == nil {
+			token = cookie.Value
+		}
+
+		if !safeMethod(r.Method) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxFormSize)
+			sent := r.Header.Get(csrfHeaderName)
+			if sent == "" {
+				// Files beyond maxAttachmentSize are spooled to disk, but no further than maxFormSize
+				var tooLarge *http.MaxBytesError
+				if err := r.ParseMultipartForm(maxAttachmentSize); errors.As(err, &tooLarge) {
+					http.Error(w, fmt.Sprintf("Request too large; files may be up to %d MB", maxAttachmentSize>>20), http.StatusRequestEntityTooLarge)
+					return
+				}
+				sent = r.PostFormValue(csrfFieldName)
+			}
+			if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				http.Error(w, "Forbidden: this form has expired or was sent from another site (missing or invalid CSRF token). Reload the page and try again.", http.StatusForbidden)
+				return
+			}
+		}
+
+		if token == "" {
+			var err error
+			if token, err = auth.NewSessionToken(); err != nil {
+				log.Printf("Error generating CSRF token: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			http.SetCookie(w, h.sessionCookie(r, csrfCookieName, token, 0))
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), csrfContextKey{}, token)))
+	})
+}
+
+// safeMethod reports whether a request method only reads
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isJSONRequest reports whether the request body is declared as JSON
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// csrfToken returns the CSRF token CSRFProtect attached to the request
+func csrfToken(r *http.Request) string {
+	token, _ := r.Context().Value(csrfContextKey{}).(string)
+	return token
+}
+
+// render executes a page template, giving its forms the request's CSRF token through
+// {{csrfField}} and its navigation the user through {{currentUser}} and {{isAdmin}}.
+// The parsed templates are cloned so concurrent requests each get their own.
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, name string, data interface{}) error {
+	templates, err := h.templates.Clone()
+	if err != nil {
+		return err
+	}
+
+	field := template.HTML(`<input type="hidden" name="` + csrfFieldName + `" value="` + template.HTMLEscapeString(csrfToken(r)) + `">`)
+	templates.Funcs(template.FuncMap{
+		"csrfField":   func() template.HTML { return field },
+		"currentUser": func() *models.User { return currentUser(r) },
+		"isAdmin":     func() bool { return isAdmin(r) },
+	})
+
+	return templates.ExecuteTemplate(w, name, data)
+}
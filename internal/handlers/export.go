@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// csvHeader is the header row of exported files. It matches the column order
+// parseCSVRecord expects, so an export can be imported again unchanged.
+var csvHeader = []string{
+	"Date Applied", "Job Title", "Company", "Status", "Job URL", "Notes", "Tags",
+	"Salary Min", "Salary Max", "Currency", "Equity", "Location", "Work Mode",
+}
+
+// jobCSVRecord converts a JobApplication to a CSV record in csvHeader order
+func jobCSVRecord(job *models.JobApplication) []string {
+	return []string{
+		job.DateApplied.Format("2006-01-02"),
+		job.JobTitle,
+		job.Company,
+		job.Status,
+		job.JobURL,
+		job.Notes,
+		strings.Join(job.Tags, ", "),
+		csvAmount(job.SalaryMin),
+		csvAmount(job.SalaryMax),
+		job.SalaryCurrency,
+		job.Equity,
+		job.Location,
+		job.WorkMode,
+	}
+}
+
+// csvAmount formats an optional amount for export, leaving unknown amounts empty
+func csvAmount(amount *int) string {
+	if amount == nil {
+		return ""
+	}
+	return strconv.Itoa(*amount)
+}
+
+// ExportCSVHandler downloads job applications as CSV, respecting the ?status= and ?tag= filters
+func (h *Handler) ExportCSVHandler(w http.ResponseWriter, r *http.Request) {
+	opts := listFilter(r)
+	status := opts.Status
+
+	jobs, _, err := h.store(r).ListJobApplications(r.Context(), opts)
+	if err != nil {
+		log.Printf("Error getting job applications: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	filename := "hunter-seeker-" + time.Now().Format("2006-01-02")
+	if status != "" {
+		filename += "-" + strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '-'
+		}, strings.ToLower(status))
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".csv"))
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		log.Printf("Error writing CSV: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if err := writer.Write(jobCSVRecord(job)); err != nil {
+			log.Printf("Error writing CSV: %v", err)
+			return
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("Error writing CSV: %v", err)
+	}
+}
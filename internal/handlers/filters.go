@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// matchAny is the ?match= value that shows applications with any of the selected tags
+// instead of all of them
+const matchAny = "any"
+
+// listFilters reads the ?tag=, ?match=, ?work_mode=, ?location= and ?min_salary= filters.
+// Invalid values are ignored.
+func listFilters(query url.Values, status string) database.ListOptions {
+	opts := database.ListOptions{
+		Status:       status,
+		Tags:         models.NormalizeTags(query["tag"]),
+		MatchAllTags: query.Get("match") != matchAny,
+		Location:     strings.TrimSpace(query.Get("location")),
+	}
+
+	if mode, ok := models.ParseWorkMode(query.Get("work_mode")); ok {
+		opts.WorkMode = mode
+	}
+	if minSalary, err := parseSalary(query.Get("min_salary")); err == nil {
+		opts.MinSalary = minSalary
+	}
+
+	return opts
+}
+
+// listFilter reads the filters of an unpaginated list, including ?status=, sorted newest first
+func listFilter(r *http.Request) database.ListOptions {
+	query := r.URL.Query()
+	opts := listFilters(query, query.Get("status"))
+	opts.Sort = database.SortDate
+	opts.Descending = true
+	return opts
+}
+
+// filterValues returns the query parameters selecting the filters of opts
+func filterValues(opts database.ListOptions) url.Values {
+	values := url.Values{}
+	if opts.Status != "" {
+		values.Set("status", opts.Status)
+	}
+	if len(opts.Tags) > 0 {
+		values["tag"] = opts.Tags
+		if !opts.MatchAllTags {
+			values.Set("match", matchAny)
+		}
+	}
+	if opts.WorkMode != "" {
+		values.Set("work_mode", opts.WorkMode)
+	}
+	if opts.Location != "" {
+		values.Set("location", opts.Location)
+	}
+	if opts.MinSalary != nil {
+		values.Set("min_salary", strconv.Itoa(*opts.MinSalary))
+	}
+	return values
+}
+
+// parseSalary reads an optional salary amount, ignoring currency symbols, spaces and
+// thousands separators and accepting a k suffix, so "$120,000" and "120k" are both 120000.
+// Amounts that are not finite or do not fit in an int32 are rejected.
+func parseSalary(value string) (*int, error) {
+	value = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == 'k' || r == 'K' || r == '.' {
+			return r
+		}
+		if r == ',' || r == ' ' || r == '$' || r == '€' || r == '£' || r == '¥' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(value))
+	if value == "" {
+		return nil, nil
+	}
+
+	multiplier := 1.0
+	if strings.HasSuffix(value, "k") || strings.HasSuffix(value, "K") {
+		multiplier = 1000
+		value = value[:len(value)-1]
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	amount *= multiplier
+	if err != nil || math.IsNaN(amount) || amount < 0 || amount > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid salary %q", value)
+	}
+
+	salary := int(amount)
+	return &salary, nil
+}
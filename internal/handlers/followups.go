@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/followup"
+	"hunter-seeker/internal/models"
+)
+
+// dashboardAttentionLimit is the number of applications needing attention shown on the dashboard
+const dashboardAttentionLimit = 5
+
+// defaultSnoozeDays is how far a follow-up is pushed back when no number of days is given
+const defaultSnoozeDays = 7
+
+// FollowUpsHandler renders the applications needing attention and the follow-up rules
+func (h *Handler) FollowUpsHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := h.store(r).GetApplicationsNeedingAttention(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error getting applications needing attention: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rules, err := h.db.GetFollowUpRules(r.Context())
+	if err != nil {
+		log.Printf("Error getting follow-up rules: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	statuses := h.workflow(r.Context())
+
+	var statusMessage string
+	switch r.URL.Query().Get("success") {
+	case "saved":
+		statusMessage = "Follow-up rule saved"
+		if moved := r.URL.Query().Get("moved"); moved != "" && moved != "0" {
+			statusMessage += fmt.Sprintf("; %s silent application(s) moved to %s", moved, noResponseStatus(statuses))
+		}
+	case "deleted":
+		statusMessage = "Follow-up rule deleted"
+	}
+
+	data := struct {
+		Items            []followup.Item
+		Rules            []*models.FollowUpRule
+		Statuses         []string
+		NoResponseStatus string
+		StatusMessage    string
+	}{
+		Items:            items,
+		Rules:            rules,
+		Statuses:         statusNames(statuses),
+		NoResponseStatus: noResponseStatus(statuses),
+		StatusMessage:    statusMessage,
+	}
+
+	if err := h.render(w, r, "follow_ups.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// SaveFollowUpRuleHandler creates or replaces the follow-up rule for a status
+func (h *Handler) SaveFollowUpRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	rule := &models.FollowUpRule{Status: strings.TrimSpace(r.FormValue("status"))}
+	if rule.Status == "" {
+		http.Error(w, "Status is required", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.db.ResolveStatus(r.Context(), rule.Status)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidStatus) {
+			http.Error(w, "Unknown status", http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error checking status: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	rule.Status = status
+
+	rule.FollowUpDays, err = strconv.Atoi(r.FormValue("follow_up_days"))
+	if err != nil || rule.FollowUpDays < 1 {
+		http.Error(w, "Follow-up days must be a positive number", http.StatusBadRequest)
+		return
+	}
+
+	if value := r.FormValue("no_response_days"); value != "" {
+		rule.NoResponseDays, err = strconv.Atoi(value)
+		if err != nil || rule.NoResponseDays < 0 {
+			http.Error(w, "No response days must be zero or a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.db.SaveFollowUpRule(r.Context(), rule); err != nil {
+		log.Printf("Error saving follow-up rule: %v", err)
+		http.Error(w, "Failed to save follow-up rule", http.StatusInternalServerError)
+		return
+	}
+
+	// Apply a new no-response period straight away rather than waiting for the next check
+	moved, err := h.db.MoveSilentApplications(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error moving silent applications: %v", err)
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/follow-ups?success=saved&moved=%d", moved), http.StatusSeeOther)
+}
+
+// DeleteFollowUpRuleHandler deletes a follow-up rule
+func (h *Handler) DeleteFollowUpRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteFollowUpRule(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrFollowUpRuleNotFound) {
+			http.Error(w, "Follow-up rule not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error deleting follow-up rule: %v", err)
+		http.Error(w, "Failed to delete follow-up rule", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/follow-ups?success=deleted", http.StatusSeeOther)
+}
+
+// SnoozeFollowUpHandler pushes an application's follow-up date back by ?days= (default a week).
+// It redirects to the local path in return_to, or the dashboard.
+func (h *Handler) SnoozeFollowUpHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	days := defaultSnoozeDays
+	if value := r.FormValue("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 {
+			http.Error(w, "Days must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	date := followup.Today(time.Now()).AddDate(0, 0, days)
+	if err := h.store(r).SetNextActionDate(r.Context(), id, &date); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			http.Error(w, "Job application not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error snoozing follow-up: %v", err)
+		http.Error(w, "Failed to snooze follow-up", http.StatusInternalServerError)
+		return
+	}
+
+	returnTo := r.FormValue("return_to")
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") {
+		returnTo = "/"
+	}
+	http.Redirect(w, r, returnTo, http.StatusSeeOther)
+}
+
+// APIFollowUpsHandler returns the applications needing attention as JSON
+func (h *Handler) APIFollowUpsHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := h.store(r).GetApplicationsNeedingAttention(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error getting applications needing attention: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to get follow-ups")
+		return
+	}
+
+	if items == nil {
+		items = []followup.Item{}
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
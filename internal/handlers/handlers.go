@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,18 +13,27 @@ import (
 	"time"
 
 	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/followup"
 	"hunter-seeker/internal/models"
 
 	"github.com/gorilla/mux"
 )
 
 type Handler struct {
-	db        *database.DB
+	db        database.Store
 	templates *template.Template
+	imports   *importStore
+	auth      *authState
 }
 
 // New creates a new handler instance
-func New(db *database.DB, templateDir string) (*Handler, error) {
+func New(db database.Store, templateDir string) (*Handler, error) {
+	h := &Handler{
+		db:      db,
+		imports: newImportStore(),
+		auth:    &authState{},
+	}
+
 	// Create template functions
 	funcMap := template.FuncMap{
 		"replace": func(s, old, new string) string {
@@ -38,6 +46,33 @@ func New(db *database.DB, templateDir string) (*Handler, error) {
 		"formatDateTime": func(t time.Time) string {
 			return t.Format("Jan 2, 2006 at 3:04 PM")
 		},
+		"formatDateTimeLocal": func(t time.Time) string {
+			return t.Format(dateTimeLocalFormat)
+		},
+		"percent": func(f float64) string {
+			return fmt.Sprintf("%.0f%%", f*100)
+		},
+		"ratio": func(part, whole int) float64 {
+			if whole == 0 {
+				return 0
+			}
+			return float64(part) / float64(whole)
+		},
+		"formatDays": func(days *float64) string {
+			if days == nil {
+				return "–"
+			}
+			return fmt.Sprintf("%.1f", *days)
+		},
+		"highlight": highlightHTML,
+		"join":      strings.Join,
+		"workMode":  models.WorkModeLabel,
+		// loginRequired shows the log out button when a login is in use
+		"loginRequired": h.loginRequired,
+		// csrfField, currentUser and isAdmin are replaced for each request by render
+		"csrfField":   func() template.HTML { return "" },
+		"currentUser": func() *models.User { return nil },
+		"isAdmin":     func() bool { return false },
 	}
 
 	templates, err := template.New("").Funcs(funcMap).ParseGlob(filepath.Join(templateDir, "*.html"))
@@ -45,33 +80,12 @@ func New(db *database.DB, templateDir string) (*Handler, error) {
 		return nil, fmt.Errorf("failed to parse templates: %w", err)
 	}
 
-	return &Handler{
-		db:        db,
-		templates: templates,
-	}, nil
+	h.templates = templates
+	return h, nil
 }
 
-// HomeHandler renders the main page with all job applications
+// HomeHandler renders the main page with a page of job applications
 func (h *Handler) HomeHandler(w http.ResponseWriter, r *http.Request) {
-	jobs, err := h.db.GetAllJobApplications()
-	if err != nil {
-		log.Printf("Error getting job applications: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	statusCounts, err := h.db.GetStatusCounts()
-	if err != nil {
-		log.Printf("Error getting status counts: %v", err)
-		statusCounts = make(map[string]int)
-	}
-
-	totalCount, err := h.db.GetTotalJobApplicationCount()
-	if err != nil {
-		log.Printf("Error getting total count: %v", err)
-		totalCount = 0
-	}
-
 	// Handle status messages from delete operations
 	var statusMessage string
 	var statusType string
@@ -96,38 +110,126 @@ func (h *Handler) HomeHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	data := struct {
-		Jobs          []*models.JobApplication
-		StatusCounts  map[string]int
-		TotalCount    int
-		Statuses      []string
-		CurrentFilter string
-		StatusMessage string
-		StatusType    string
-	}{
-		Jobs:          jobs,
-		StatusCounts:  statusCounts,
-		TotalCount:    totalCount,
-		Statuses:      models.GetCommonStatuses(),
-		CurrentFilter: "",
-		StatusMessage: statusMessage,
-		StatusType:    statusType,
+	h.renderDashboard(w, r, "/", "", statusMessage, statusType)
+}
+
+// renderDashboard renders index.html with one page of applications, sorted and
+// paginated according to the request's query parameters
+func (h *Handler) renderDashboard(w http.ResponseWriter, r *http.Request, path, status, statusMessage, statusType string) {
+	opts, page := parseListOptions(r, status)
+
+	jobs, total, err := h.store(r).ListJobApplications(r.Context(), opts)
+	if err != nil {
+		log.Printf("Error getting job applications: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	pages := newPagination(path, opts, page, total)
+	if page > pages.TotalPages {
+		http.Redirect(w, r, pages.Pages[len(pages.Pages)-1].URL, http.StatusSeeOther)
+		return
+	}
+
+	statusCounts, err := h.store(r).GetStatusCounts(r.Context())
+	if err != nil {
+		log.Printf("Error getting status counts: %v", err)
+		statusCounts = make(map[string]int)
+	}
+
+	totalCount, err := h.store(r).GetTotalJobApplicationCount(r.Context())
+	if err != nil {
+		log.Printf("Error getting total count: %v", err)
+		totalCount = 0
+	}
+
+	upcoming, err := h.store(r).GetUpcomingInterviews(r.Context(), time.Now(), upcomingInterviewLimit)
+	if err != nil {
+		log.Printf("Error getting upcoming interviews: %v", err)
+	}
+
+	attention, err := h.store(r).GetApplicationsNeedingAttention(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error getting applications needing attention: %v", err)
+	}
+	attentionTotal := len(attention)
+	if len(attention) > dashboardAttentionLimit {
+		attention = attention[:dashboardAttentionLimit]
 	}
 
-	if err := h.templates.ExecuteTemplate(w, "index.html", data); err != nil {
+	tags, err := h.store(r).GetTags(r.Context())
+	if err != nil {
+		log.Printf("Error getting tags: %v", err)
+	}
+
+	calendarToken, err := h.store(r).CalendarToken(r.Context())
+	if err != nil {
+		log.Printf("Error getting calendar token: %v", err)
+	}
+
+	statuses := h.workflow(r.Context())
+
+	data := struct {
+		Jobs               []*models.JobApplication
+		StatusCounts       map[string]int
+		TotalCount         int
+		Statuses           []string
+		StatusColors       map[string]string
+		CurrentFilter      string
+		TagFilter          *tagFilter
+		Filter             database.ListOptions
+		WorkModes          []string
+		StatusMessage      string
+		StatusType         string
+		Pagination         *pagination
+		UpcomingInterviews []*models.Interview
+		NeedsAttention     []followup.Item
+		AttentionTotal     int
+		ReturnTo           string
+		CalendarToken      string
+	}{
+		Jobs:               jobs,
+		StatusCounts:       statusCounts,
+		TotalCount:         totalCount,
+		Statuses:           statusNames(statuses),
+		StatusColors:       statusColors(statuses),
+		CurrentFilter:      status,
+		TagFilter:          newTagFilter(tags, opts),
+		Filter:             opts,
+		WorkModes:          models.GetWorkModes(),
+		StatusMessage:      statusMessage,
+		StatusType:         statusType,
+		Pagination:         pages,
+		UpcomingInterviews: upcoming,
+		NeedsAttention:     attention,
+		AttentionTotal:     attentionTotal,
+		ReturnTo:           r.URL.RequestURI(),
+		CalendarToken:      calendarToken,
+	}
+
+	if err := h.render(w, r, "index.html", data); err != nil {
 		log.Printf("Error executing template: %v", err)
 	}
 }
 
 // AddJobHandler renders the add job form
 func (h *Handler) AddJobHandler(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.store(r).GetTags(r.Context())
+	if err != nil {
+		log.Printf("Error getting tags: %v", err)
+	}
+
 	data := struct {
-		Statuses []string
+		Statuses  []string
+		Tags      []*models.Tag
+		WorkModes []string
 	}{
-		Statuses: models.GetCommonStatuses(),
+		Statuses:  statusNames(h.workflow(r.Context())),
+		Tags:      tags,
+		WorkModes: models.GetWorkModes(),
 	}
 
-	if err := h.templates.ExecuteTemplate(w, "add_job.html", data); err != nil {
+	if err := h.render(w, r, "add_job.html", data); err != nil {
 		log.Printf("Error executing template: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
@@ -154,16 +256,33 @@ func (h *Handler) CreateJobHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	nextActionDate, err := parseNextActionDate(r)
+	if err != nil {
+		http.Error(w, "Invalid follow-up date format", http.StatusBadRequest)
+		return
+	}
+
 	job := &models.JobApplication{
-		DateApplied: dateApplied,
-		JobTitle:    r.FormValue("job_title"),
-		Company:     r.FormValue("company"),
-		Status:      r.FormValue("status"),
-		JobURL:      r.FormValue("job_url"),
-		Notes:       r.FormValue("notes"),
+		DateApplied:    dateApplied,
+		JobTitle:       r.FormValue("job_title"),
+		Company:        r.FormValue("company"),
+		Status:         r.FormValue("status"),
+		JobURL:         r.FormValue("job_url"),
+		Notes:          r.FormValue("notes"),
+		NextActionDate: nextActionDate,
+		Tags:           models.ParseTags(r.FormValue("tags")),
+	}
+
+	if err := compensationFromForm(r, job); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if err := h.db.CreateJobApplication(job); err != nil {
+	if err := h.store(r).CreateJobApplication(r.Context(), job); err != nil {
+		if errors.Is(err, database.ErrInvalidStatus) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		log.Printf("Error creating job application: %v", err)
 		http.Error(w, "Failed to create job application", http.StatusInternalServerError)
 		return
@@ -172,6 +291,46 @@ func (h *Handler) CreateJobHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
+// compensationFromForm reads the salary range, equity, location and work mode from a
+// submitted form into job
+func compensationFromForm(r *http.Request, job *models.JobApplication) error {
+	var err error
+	if job.SalaryMin, err = parseSalary(r.FormValue("salary_min")); err != nil {
+		return errors.New("invalid minimum salary")
+	}
+	if job.SalaryMax, err = parseSalary(r.FormValue("salary_max")); err != nil {
+		return errors.New("invalid maximum salary")
+	}
+	job.SalaryCurrency = strings.ToUpper(strings.TrimSpace(r.FormValue("salary_currency")))
+	job.Equity = strings.TrimSpace(r.FormValue("equity"))
+	job.Location = strings.TrimSpace(r.FormValue("location"))
+
+	mode, ok := models.ParseWorkMode(r.FormValue("work_mode"))
+	if !ok {
+		return errors.New("invalid work mode")
+	}
+	job.WorkMode = mode
+
+	if _, err := job.ValidateCompensation(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseNextActionDate reads the optional follow-up date (YYYY-MM-DD) from a submitted form
+func parseNextActionDate(r *http.Request) (*time.Time, error) {
+	value := r.FormValue("next_action_date")
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
 // EditJobHandler renders the edit job form
 func (h *Handler) EditJobHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -181,22 +340,64 @@ func (h *Handler) EditJobHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	job, err := h.db.GetJobApplication(id)
+	job, err := h.store(r).GetJobApplication(r.Context(), id)
 	if err != nil {
 		log.Printf("Error getting job application: %v", err)
 		http.Error(w, "Job application not found", http.StatusNotFound)
 		return
 	}
 
+	contacts, err := h.store(r).GetAllContacts(r.Context())
+	if err != nil {
+		log.Printf("Error getting contacts: %v", err)
+	}
+
+	// Offer only the contacts that are not linked yet
+	linked := make(map[int]bool)
+	for _, contact := range job.Contacts {
+		linked[contact.ID] = true
+	}
+	var availableContacts []*models.Contact
+	for _, contact := range contacts {
+		if !linked[contact.ID] {
+			availableContacts = append(availableContacts, contact)
+		}
+	}
+
+	tags, err := h.store(r).GetTags(r.Context())
+	if err != nil {
+		log.Printf("Error getting tags: %v", err)
+	}
+
+	var company *models.Company
+	if job.CompanyID != 0 {
+		company, err = h.store(r).GetCompany(r.Context(), job.CompanyID)
+		if err != nil {
+			log.Printf("Error getting company: %v", err)
+		}
+	}
+
 	data := struct {
-		Job      *models.JobApplication
-		Statuses []string
+		Job               *models.JobApplication
+		Statuses          []string
+		AvailableContacts []*models.Contact
+		Company           *models.Company
+		Outcomes          []string
+		Tags              []*models.Tag
+		WorkModes         []string
+		AttachmentKinds   []string
 	}{
-		Job:      job,
-		Statuses: models.GetCommonStatuses(),
+		Job:               job,
+		Statuses:          allowedStatuses(h.workflow(r.Context()), job.Status),
+		AvailableContacts: availableContacts,
+		Company:           company,
+		Outcomes:          models.GetInterviewOutcomes(),
+		Tags:              tags,
+		WorkModes:         models.GetWorkModes(),
+		AttachmentKinds:   models.GetAttachmentKinds(),
 	}
 
-	if err := h.templates.ExecuteTemplate(w, "edit_job.html", data); err != nil {
+	if err := h.render(w, r, "edit_job.html", data); err != nil {
 		log.Printf("Error executing template: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
@@ -230,17 +431,34 @@ func (h *Handler) UpdateJobHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	nextActionDate, err := parseNextActionDate(r)
+	if err != nil {
+		http.Error(w, "Invalid follow-up date format", http.StatusBadRequest)
+		return
+	}
+
 	job := &models.JobApplication{
-		ID:          id,
-		DateApplied: dateApplied,
-		JobTitle:    r.FormValue("job_title"),
-		Company:     r.FormValue("company"),
-		Status:      r.FormValue("status"),
-		JobURL:      r.FormValue("job_url"),
-		Notes:       r.FormValue("notes"),
+		ID:             id,
+		DateApplied:    dateApplied,
+		JobTitle:       r.FormValue("job_title"),
+		Company:        r.FormValue("company"),
+		Status:         r.FormValue("status"),
+		JobURL:         r.FormValue("job_url"),
+		Notes:          r.FormValue("notes"),
+		NextActionDate: nextActionDate,
+		Tags:           models.ParseTags(r.FormValue("tags")),
+	}
+
+	if err := compensationFromForm(r, job); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if err := h.db.UpdateJobApplication(job); err != nil {
+	if err := h.store(r).UpdateJobApplication(r.Context(), job); err != nil {
+		if errors.Is(err, database.ErrInvalidStatus) || errors.Is(err, database.ErrTransitionNotAllowed) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		log.Printf("Error updating job application: %v", err)
 		http.Error(w, "Failed to update job application", http.StatusInternalServerError)
 		return
@@ -263,7 +481,7 @@ func (h *Handler) DeleteJobHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.db.DeleteJobApplication(id); err != nil {
+	if err := h.store(r).DeleteJobApplication(r.Context(), id); err != nil {
 		if errors.Is(err, database.ErrJobNotFound) {
 			log.Printf("Job application not found: ID %d", id)
 			http.Redirect(w, r, "/?error=notfound&id="+strconv.Itoa(id), http.StatusSeeOther)
@@ -279,58 +497,7 @@ func (h *Handler) DeleteJobHandler(w http.ResponseWriter, r *http.Request) {
 
 // FilterHandler handles filtering by status
 func (h *Handler) FilterHandler(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-
-	var jobs []*models.JobApplication
-	var err error
-
-	if status != "" {
-		jobs, err = h.db.GetJobApplicationsByStatus(status)
-	} else {
-		jobs, err = h.db.GetAllJobApplications()
-	}
-
-	if err != nil {
-		log.Printf("Error getting job applications: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	statusCounts, err := h.db.GetStatusCounts()
-	if err != nil {
-		log.Printf("Error getting status counts: %v", err)
-		statusCounts = make(map[string]int)
-	}
-
-	totalCount, err := h.db.GetTotalJobApplicationCount()
-	if err != nil {
-		log.Printf("Error getting total count: %v", err)
-		totalCount = 0
-	}
-
-	data := struct {
-		Jobs          []*models.JobApplication
-		StatusCounts  map[string]int
-		TotalCount    int
-		Statuses      []string
-		CurrentFilter string
-		StatusMessage string
-		StatusType    string
-	}{
-		Jobs:          jobs,
-		StatusCounts:  statusCounts,
-		TotalCount:    totalCount,
-		Statuses:      models.GetCommonStatuses(),
-		CurrentFilter: status,
-		StatusMessage: "",
-		StatusType:    "",
-	}
-
-	if err := h.templates.ExecuteTemplate(w, "index.html", data); err != nil {
-		log.Printf("Error executing template: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
+	h.renderDashboard(w, r, "/filter", r.URL.Query().Get("status"), "", "")
 }
 
 // DebugFilterHandler is a simple debug endpoint to test filter functionality
@@ -341,9 +508,9 @@ func (h *Handler) DebugFilterHandler(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	if status != "" {
-		jobs, err = h.db.GetJobApplicationsByStatus(status)
+		jobs, err = h.store(r).GetJobApplicationsByStatus(r.Context(), status)
 	} else {
-		jobs, err = h.db.GetAllJobApplications()
+		jobs, err = h.store(r).GetAllJobApplications(r.Context())
 	}
 
 	if err != nil {
@@ -363,7 +530,7 @@ func (h *Handler) DebugFilterHandler(w http.ResponseWriter, r *http.Request) {
 
 // StatsHandler returns job application statistics as JSON
 func (h *Handler) StatsHandler(w http.ResponseWriter, r *http.Request) {
-	statusCounts, err := h.db.GetStatusCounts()
+	statusCounts, err := h.store(r).GetStatusCounts(r.Context())
 	if err != nil {
 		log.Printf("Error getting status counts: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -376,219 +543,3 @@ func (h *Handler) StatsHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
-
-// ImportCSVHandler renders the CSV import form
-func (h *Handler) ImportCSVHandler(w http.ResponseWriter, r *http.Request) {
-	data := struct {
-		Statuses []string
-	}{
-		Statuses: models.GetCommonStatuses(),
-	}
-
-	if err := h.templates.ExecuteTemplate(w, "import_csv.html", data); err != nil {
-		log.Printf("Error executing template: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
-}
-
-// ProcessCSVHandler processes uploaded CSV file and creates job applications
-func (h *Handler) ProcessCSVHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// Parse multipart form (10MB max)
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		http.Error(w, "Failed to parse form", http.StatusBadRequest)
-		return
-	}
-
-	// Get the file from form
-	file, _, err := r.FormFile("csv_file")
-	if err != nil {
-		http.Error(w, "Failed to get CSV file", http.StatusBadRequest)
-		return
-	}
-	defer file.Close()
-
-	// Parse CSV
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		http.Error(w, "Failed to parse CSV file", http.StatusBadRequest)
-		return
-	}
-
-	if len(records) == 0 {
-		http.Error(w, "CSV file is empty", http.StatusBadRequest)
-		return
-	}
-
-	var successCount, errorCount int
-	var errors []string
-
-	// Skip header row if present
-	startIdx := 0
-	if len(records) > 0 && isHeaderRow(records[0]) {
-		startIdx = 1
-	}
-
-	// Process each row
-	for i := startIdx; i < len(records); i++ {
-		record := records[i]
-
-		// Skip empty rows
-		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
-			continue
-		}
-
-		job, err := parseCSVRecord(record)
-		if err != nil {
-			errorCount++
-			errors = append(errors, fmt.Sprintf("Row %d: %v", i+1, err))
-			continue
-		}
-
-		if err := h.db.CreateJobApplication(job); err != nil {
-			errorCount++
-			errors = append(errors, fmt.Sprintf("Row %d: Failed to save %s at %s: %v", i+1, job.JobTitle, job.Company, err))
-		} else {
-			successCount++
-		}
-	}
-
-	// Prepare response data
-	data := struct {
-		SuccessCount int
-		ErrorCount   int
-		Errors       []string
-		TotalRows    int
-	}{
-		SuccessCount: successCount,
-		ErrorCount:   errorCount,
-		Errors:       errors,
-		TotalRows:    len(records) - startIdx,
-	}
-
-	if err := h.templates.ExecuteTemplate(w, "import_result.html", data); err != nil {
-		log.Printf("Error executing template: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
-}
-
-// isHeaderRow checks if the first row looks like a header
-func isHeaderRow(record []string) bool {
-	if len(record) == 0 {
-		return false
-	}
-
-	// Check for common header keywords
-	firstCol := strings.ToLower(strings.TrimSpace(record[0]))
-	headerKeywords := []string{"date", "job", "title", "company", "position", "role"}
-
-	for _, keyword := range headerKeywords {
-		if strings.Contains(firstCol, keyword) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// parseCSVRecord converts a CSV record to a JobApplication
-func parseCSVRecord(record []string) (*models.JobApplication, error) {
-	if len(record) < 3 {
-		return nil, fmt.Errorf("insufficient columns (need at least: date_applied, job_title, company)")
-	}
-
-	// Parse date (required)
-	dateStr := strings.TrimSpace(record[0])
-	dateApplied, err := parseDate(dateStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid date format '%s': %v", dateStr, err)
-	}
-
-	// Job title (required)
-	jobTitle := strings.TrimSpace(record[1])
-	if jobTitle == "" {
-		return nil, fmt.Errorf("job title is required")
-	}
-
-	// Company (required)
-	company := strings.TrimSpace(record[2])
-	if company == "" {
-		return nil, fmt.Errorf("company is required")
-	}
-
-	job := &models.JobApplication{
-		DateApplied: dateApplied,
-		JobTitle:    jobTitle,
-		Company:     company,
-		Status:      models.StatusApplied, // Default status
-	}
-
-	// Status (optional, column 4)
-	if len(record) > 3 {
-		status := strings.TrimSpace(record[3])
-		if status != "" {
-			job.Status = status
-		}
-	}
-
-	// Job URL (optional, column 5)
-	if len(record) > 4 {
-		job.JobURL = strings.TrimSpace(record[4])
-	}
-
-	// Notes (optional, column 6)
-	if len(record) > 5 {
-		job.Notes = strings.TrimSpace(record[5])
-	}
-
-	return job, nil
-}
-
-// parseDate attempts to parse various date formats
-func parseDate(dateStr string) (time.Time, error) {
-	if dateStr == "" {
-		return time.Time{}, fmt.Errorf("date is required")
-	}
-
-	// Try common date formats
-	formats := []string{
-		"2006-01-02",      // ISO format (YYYY-MM-DD)
-		"01/02/2006",      // US format (MM/DD/YYYY)
-		"1/2/2006",        // US format without leading zeros
-		"02/01/2006",      // Some other format (DD/MM/YYYY)
-		"2/1/2006",        // Without leading zeros
-		"2006/01/02",      // YYYY/MM/DD
-		"2006/1/2",        // YYYY/M/D
-		"Jan 2, 2006",     // Month name format
-		"January 2, 2006", // Full month name
-		"2 Jan 2006",      // European style
-		"2006-1-2",        // ISO without leading zeros
-		"Jan 2 2006",      // Month name without comma
-		"January 2 2006",  // Full month name without comma
-		"Feb 2 2006",      // Short month name without comma
-		"Feb 20 2006",     // Short month name without comma (actual example)
-		"March 2 2006",    // Month name variations
-		"Apr 2 2006",
-		"May 2 2006",
-		"Jun 2 2006",
-		"Jul 2 2006",
-		"Aug 2 2006",
-		"Sep 2 2006",
-		"Oct 2 2006",
-		"Nov 2 2006",
-		"Dec 2 2006",
-	}
-
-	for _, format := range formats {
-		if date, err := time.Parse(format, dateStr); err == nil {
-			return date, nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("unrecognized date format")
-}
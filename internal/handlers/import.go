@@ -0,0 +1,743 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// Duplicate handling modes for CSV import. A row is a duplicate when it has the
+// same job URL, or the same company, job title and date as an existing application.
+const (
+	duplicateSkip   = "skip"
+	duplicateUpdate = "update"
+	duplicateCreate = "create"
+)
+
+const (
+	// pendingImportTTL is how long an uploaded file waits for the user to confirm the import
+	pendingImportTTL = 30 * time.Minute
+	// previewRows is the number of data rows shown on the preview page
+	previewRows = 10
+)
+
+// importField is a job application field a CSV column can be mapped to
+type importField struct {
+	Name     string
+	Label    string
+	Required bool
+	// aliases are normalized header names that map to this field automatically
+	aliases []string
+}
+
+// importFields lists the mappable fields in the column order of the classic import format
+var importFields = []importField{
+	{Name: "date_applied", Label: "Date Applied", Required: true, aliases: []string{"date applied", "date", "applied", "applied on", "applied date", "application date", "date of application", "submitted", "date submitted"}},
+	{Name: "job_title", Label: "Job Title", Required: true, aliases: []string{"job title", "title", "position", "position title", "role", "job", "job name", "opening"}},
+	{Name: "company", Label: "Company", Required: true, aliases: []string{"company", "company name", "employer", "organization", "organisation", "org", "business"}},
+	{Name: "status", Label: "Status", aliases: []string{"status", "stage", "state", "application status", "progress"}},
+	{Name: "job_url", Label: "Job URL", aliases: []string{"job url", "url", "link", "job link", "posting", "job posting", "posting url", "listing", "website"}},
+	{Name: "notes", Label: "Notes", aliases: []string{"notes", "note", "comments", "comment", "description", "details"}},
+	{Name: "tags", Label: "Tags", aliases: []string{"tags", "tag", "labels", "label", "keywords"}},
+	{Name: "salary_min", Label: "Salary Min", aliases: []string{"salary min", "min salary", "minimum salary", "salary from", "salary low", "base salary", "salary"}},
+	{Name: "salary_max", Label: "Salary Max", aliases: []string{"salary max", "max salary", "maximum salary", "salary to", "salary high"}},
+	{Name: "salary_currency", Label: "Currency", aliases: []string{"currency", "salary currency"}},
+	{Name: "equity", Label: "Equity", aliases: []string{"equity", "stock", "options", "stock options"}},
+	{Name: "location", Label: "Location", aliases: []string{"location", "city", "office", "office location"}},
+	{Name: "work_mode", Label: "Work Mode", aliases: []string{"work mode", "remote", "workplace", "workplace type", "work type", "arrangement", "work arrangement"}},
+}
+
+// columnMapping maps import field names to CSV column indexes
+type columnMapping map[string]int
+
+// column returns the trimmed value of field in record, or "" if it is unmapped or missing
+func (m columnMapping) column(record []string, field string) string {
+	index, ok := m[field]
+	if !ok || index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// pendingImport is an uploaded CSV file waiting for the user to confirm its column mapping
+type pendingImport struct {
+	// userID uploaded the file, and only they can import it
+	userID   int
+	filename string
+	records  [][]string
+	created  time.Time
+}
+
+// importStore keeps uploaded CSV files in memory between the preview and confirm steps
+type importStore struct {
+	mu      sync.Mutex
+	imports map[string]*pendingImport
+}
+
+func newImportStore() *importStore {
+	return &importStore{imports: make(map[string]*pendingImport)}
+}
+
+// add stores an upload and returns the token that identifies it
+func (s *importStore) add(p *pendingImport) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate import token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop uploads that were never confirmed
+	for key, pending := range s.imports {
+		if time.Since(pending.created) > pendingImportTTL {
+			delete(s.imports, key)
+		}
+	}
+
+	s.imports[token] = p
+	return token, nil
+}
+
+// get returns a user's upload for token, or nil if it does not exist or has expired
+func (s *importStore) get(token string, userID int) *pendingImport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.imports[token]
+	if !ok || pending.userID != userID || time.Since(pending.created) > pendingImportTTL {
+		return nil
+	}
+	return pending
+}
+
+// remove deletes the upload for token once it has been imported
+func (s *importStore) remove(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.imports, token)
+}
+
+// importResult summarizes what happened to each row of an import
+type importResult struct {
+	SuccessCount  int
+	UpdatedCount  int
+	SkippedCount  int
+	ErrorCount    int
+	Errors        []string
+	Duplicates    []string
+	DuplicateMode string
+	TotalRows     int
+}
+
+// previewColumn is a CSV column on the preview page with the field it is mapped to
+type previewColumn struct {
+	Index  int
+	Header string
+	Field  string
+}
+
+// previewRow is a data row on the preview page, parsed with the current mapping
+type previewRow struct {
+	Number int
+	Values []string
+	Job    *models.JobApplication
+	Error  string
+}
+
+// ImportCSVHandler renders the CSV import form
+func (h *Handler) ImportCSVHandler(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Statuses []string
+	}{
+		Statuses: statusNames(h.workflow(r.Context())),
+	}
+
+	if err := h.render(w, r, "import_csv.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ProcessCSVHandler parses an uploaded CSV file and shows a preview with an
+// automatically detected column mapping. Nothing is saved until the import is confirmed.
+func (h *Handler) ProcessCSVHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Parse multipart form (10MB max)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	// Get the file from form
+	file, header, err := r.FormFile("csv_file")
+	if err != nil {
+		http.Error(w, "Failed to get CSV file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// Parse CSV, allowing rows with differing column counts
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		http.Error(w, "Failed to parse CSV file", http.StatusBadRequest)
+		return
+	}
+
+	if len(records) == 0 {
+		http.Error(w, "CSV file is empty", http.StatusBadRequest)
+		return
+	}
+
+	pending := &pendingImport{userID: h.store(r).UserID(), filename: header.Filename, records: records, created: time.Now()}
+	token, err := h.imports.add(pending)
+	if err != nil {
+		log.Printf("Error storing CSV import: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	mapping, hasHeader := autoMapColumns(records[0])
+	h.renderImportPreview(w, r, token, pending, mapping, hasHeader, duplicateSkip, nil)
+}
+
+// PreviewCSVHandler re-renders the preview of an uploaded file with the mapping chosen by the user
+func (h *Handler) PreviewCSVHandler(w http.ResponseWriter, r *http.Request) {
+	token, pending, ok := h.pendingImportFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	mapping, hasHeader, duplicateMode, err := mappingFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.renderImportPreview(w, r, token, pending, mapping, hasHeader, duplicateMode, mapping.validate())
+}
+
+// ConfirmCSVHandler imports a previously uploaded file using the confirmed column mapping
+func (h *Handler) ConfirmCSVHandler(w http.ResponseWriter, r *http.Request) {
+	token, pending, ok := h.pendingImportFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	mapping, hasHeader, duplicateMode, err := mappingFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Send the user back to the preview until every required field is mapped
+	if problems := mapping.validate(); len(problems) > 0 {
+		h.renderImportPreview(w, r, token, pending, mapping, hasHeader, duplicateMode, problems)
+		return
+	}
+
+	result := h.importRecords(r.Context(), h.store(r), pending.records, mapping, hasHeader, duplicateMode)
+	h.imports.remove(token)
+
+	if err := h.render(w, r, "import_result.html", result); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// pendingImportFromForm looks up the upload referenced by the form's token
+func (h *Handler) pendingImportFromForm(w http.ResponseWriter, r *http.Request) (string, *pendingImport, bool) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	token := r.FormValue("token")
+	pending := h.imports.get(token, h.store(r).UserID())
+	if pending == nil {
+		http.Error(w, "This import has expired or was already completed. Please upload the file again.", http.StatusNotFound)
+		return "", nil, false
+	}
+
+	return token, pending, true
+}
+
+// renderImportPreview shows the uploaded rows and how they will be imported with mapping
+func (h *Handler) renderImportPreview(w http.ResponseWriter, r *http.Request, token string, pending *pendingImport, mapping columnMapping, hasHeader bool, duplicateMode string, problems []string) {
+	columnCount := 0
+	for _, record := range pending.records {
+		if len(record) > columnCount {
+			columnCount = len(record)
+		}
+	}
+
+	fieldByColumn := make(map[int]string)
+	for field, index := range mapping {
+		fieldByColumn[index] = field
+	}
+
+	columns := make([]previewColumn, columnCount)
+	for i := range columns {
+		columns[i] = previewColumn{Index: i, Header: fmt.Sprintf("Column %d", i+1), Field: fieldByColumn[i]}
+		if hasHeader && i < len(pending.records[0]) && strings.TrimSpace(pending.records[0][i]) != "" {
+			columns[i].Header = strings.TrimSpace(pending.records[0][i])
+		}
+	}
+
+	dataRows := pending.records
+	firstRow := 1
+	if hasHeader {
+		dataRows = dataRows[1:]
+		firstRow = 2
+	}
+
+	statuses := statusNames(h.workflow(r.Context()))
+
+	var rows []previewRow
+	for i, record := range dataRows {
+		if len(rows) == previewRows {
+			break
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		row := previewRow{Number: firstRow + i, Values: make([]string, columnCount)}
+		copy(row.Values, record)
+		if job, err := parseCSVRecord(record, mapping, statuses); err != nil {
+			row.Error = err.Error()
+		} else {
+			row.Job = job
+		}
+		rows = append(rows, row)
+	}
+
+	data := struct {
+		Token         string
+		Filename      string
+		Columns       []previewColumn
+		Fields        []importField
+		Rows          []previewRow
+		TotalRows     int
+		HasHeader     bool
+		DuplicateMode string
+		Problems      []string
+	}{
+		Token:         token,
+		Filename:      pending.filename,
+		Columns:       columns,
+		Fields:        importFields,
+		Rows:          rows,
+		TotalRows:     len(dataRows),
+		HasHeader:     hasHeader,
+		DuplicateMode: duplicateMode,
+		Problems:      problems,
+	}
+
+	if err := h.render(w, r, "import_preview.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// importRecords saves the rows of a CSV file as the user's applications using mapping,
+// handling duplicates per duplicateMode
+func (h *Handler) importRecords(ctx context.Context, db database.Store, records [][]string, mapping columnMapping, hasHeader bool, duplicateMode string) *importResult {
+	result := &importResult{DuplicateMode: duplicateMode}
+
+	startIdx := 0
+	if hasHeader {
+		startIdx = 1
+	}
+	result.TotalRows = len(records) - startIdx
+
+	statuses := statusNames(h.workflow(ctx))
+
+	// Process each row
+	for i := startIdx; i < len(records); i++ {
+		record := records[i]
+
+		// Skip empty rows
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		job, err := parseCSVRecord(record, mapping, statuses)
+		if err != nil {
+			result.ErrorCount++
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: %v", i+1, err))
+			continue
+		}
+
+		if duplicateMode != duplicateCreate {
+			existing, err := db.FindDuplicateJobApplication(ctx, job)
+			if err != nil {
+				result.ErrorCount++
+				result.Errors = append(result.Errors, fmt.Sprintf("Row %d: Failed to check for duplicates of %s at %s: %v", i+1, job.JobTitle, job.Company, err))
+				continue
+			}
+
+			if existing != nil {
+				if duplicateMode == duplicateSkip {
+					result.SkippedCount++
+					result.Duplicates = append(result.Duplicates, fmt.Sprintf("Row %d: Skipped %s at %s (already tracked as #%d)", i+1, job.JobTitle, job.Company, existing.ID))
+					continue
+				}
+
+				job.ID = existing.ID
+				keepUnmappedFields(job, existing, mapping)
+				if err := db.UpdateJobApplication(ctx, job); err != nil {
+					result.ErrorCount++
+					result.Errors = append(result.Errors, fmt.Sprintf("Row %d: Failed to update %s at %s: %v", i+1, job.JobTitle, job.Company, err))
+				} else {
+					result.UpdatedCount++
+					result.Duplicates = append(result.Duplicates, fmt.Sprintf("Row %d: Updated %s at %s (#%d)", i+1, job.JobTitle, job.Company, existing.ID))
+				}
+				continue
+			}
+		}
+
+		if err := db.CreateJobApplication(ctx, job); err != nil {
+			result.ErrorCount++
+			result.Errors = append(result.Errors, fmt.Sprintf("Row %d: Failed to save %s at %s: %v", i+1, job.JobTitle, job.Company, err))
+		} else {
+			result.SuccessCount++
+		}
+	}
+
+	return result
+}
+
+// keepUnmappedFields copies the fields added after the classic import format from
+// existing onto job when the file has no column for them, so updating from an older
+// file does not clear them. Files never have a follow-up date, so it is always kept.
+func keepUnmappedFields(job, existing *models.JobApplication, mapping columnMapping) {
+	job.NextActionDate = existing.NextActionDate
+
+	unmapped := func(field string) bool {
+		_, ok := mapping[field]
+		return !ok
+	}
+
+	if unmapped("tags") {
+		job.Tags = existing.Tags
+	}
+	if unmapped("salary_min") && unmapped("salary_max") {
+		job.SalaryMin, job.SalaryMax = existing.SalaryMin, existing.SalaryMax
+	}
+	if unmapped("salary_currency") {
+		job.SalaryCurrency = existing.SalaryCurrency
+	}
+	if unmapped("equity") {
+		job.Equity = existing.Equity
+	}
+	if unmapped("location") {
+		job.Location = existing.Location
+	}
+	if unmapped("work_mode") {
+		job.WorkMode = existing.WorkMode
+	}
+}
+
+// mappingFromForm reads the column mapping, header and duplicate options from the preview form.
+// Each column is submitted as column_<index>=<field name>, with an empty value for ignored columns.
+func mappingFromForm(r *http.Request) (columnMapping, bool, string, error) {
+	mapping := make(columnMapping)
+	for key, values := range r.Form {
+		if !strings.HasPrefix(key, "column_") || len(values) == 0 || values[0] == "" {
+			continue
+		}
+
+		index, err := strconv.Atoi(strings.TrimPrefix(key, "column_"))
+		if err != nil || index < 0 {
+			return nil, false, "", fmt.Errorf("invalid column %q", key)
+		}
+
+		field := values[0]
+		if !isImportField(field) {
+			return nil, false, "", fmt.Errorf("unknown field %q", field)
+		}
+		if other, exists := mapping[field]; exists && other != index {
+			return nil, false, "", fmt.Errorf("%s is mapped to more than one column", field)
+		}
+		mapping[field] = index
+	}
+
+	duplicateMode := r.FormValue("duplicates")
+	switch duplicateMode {
+	case duplicateSkip, duplicateUpdate, duplicateCreate:
+	case "":
+		duplicateMode = duplicateSkip
+	default:
+		return nil, false, "", fmt.Errorf("invalid duplicate handling option")
+	}
+
+	return mapping, r.FormValue("has_header") != "", duplicateMode, nil
+}
+
+// validate returns a message for every required field that is not mapped to a column
+func (m columnMapping) validate() []string {
+	var problems []string
+	for _, field := range importFields {
+		if _, ok := m[field.Name]; field.Required && !ok {
+			problems = append(problems, fmt.Sprintf("Choose the column that contains %s", field.Label))
+		}
+	}
+	return problems
+}
+
+func isImportField(name string) bool {
+	for _, field := range importFields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHeader lowercases a header and turns separators into single spaces
+func normalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	header = strings.NewReplacer("_", " ", "-", " ", ".", " ", ":", " ").Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
+
+// autoMapColumns guesses the column mapping from the first row. If it looks like a
+// header, columns are matched by name (exact aliases first, then partial matches);
+// otherwise the classic fixed column order is assumed.
+func autoMapColumns(firstRow []string) (columnMapping, bool) {
+	mapping := make(columnMapping)
+	headers := make([]string, len(firstRow))
+	for i, cell := range firstRow {
+		headers[i] = normalizeHeader(cell)
+	}
+
+	assign := func(matches func(header string, field importField) bool) {
+		for _, field := range importFields {
+			if _, done := mapping[field.Name]; done {
+				continue
+			}
+			for i, header := range headers {
+				if header == "" || columnTaken(mapping, i) {
+					continue
+				}
+				if matches(header, field) {
+					mapping[field.Name] = i
+					break
+				}
+			}
+		}
+	}
+
+	assign(func(header string, field importField) bool {
+		for _, alias := range field.aliases {
+			if header == alias {
+				return true
+			}
+		}
+		return false
+	})
+	assign(func(header string, field importField) bool {
+		for _, alias := range field.aliases {
+			if len(alias) > 3 && strings.Contains(header, alias) {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Data rows always contain a date, headers never do
+	looksLikeData := false
+	for _, cell := range firstRow {
+		if _, err := parseDate(strings.TrimSpace(cell)); err == nil {
+			looksLikeData = true
+			break
+		}
+	}
+
+	if (len(mapping) > 0 && !looksLikeData) || isHeaderRow(firstRow) {
+		return mapping, true
+	}
+	mapping = make(columnMapping)
+
+	// No header: fall back to the classic column order
+	for i, field := range importFields {
+		if i < len(firstRow) {
+			mapping[field.Name] = i
+		}
+	}
+	return mapping, false
+}
+
+func columnTaken(mapping columnMapping, index int) bool {
+	for _, i := range mapping {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyRecord reports whether every cell of a CSV row is blank
+func isEmptyRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isHeaderRow checks if the first row looks like a header
+func isHeaderRow(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+
+	// Check for common header keywords
+	firstCol := strings.ToLower(strings.TrimSpace(record[0]))
+	headerKeywords := []string{"date", "job", "title", "company", "position", "role"}
+
+	for _, keyword := range headerKeywords {
+		if strings.Contains(firstCol, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseCSVRecord converts a CSV record to a JobApplication using the given column mapping.
+// The status must be one of statuses, matched case-insensitively, and defaults to the first.
+func parseCSVRecord(record []string, mapping columnMapping, statuses []string) (*models.JobApplication, error) {
+	// Parse date (required)
+	dateStr := mapping.column(record, "date_applied")
+	dateApplied, err := parseDate(dateStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format '%s': %v", dateStr, err)
+	}
+
+	// Job title (required)
+	jobTitle := mapping.column(record, "job_title")
+	if jobTitle == "" {
+		return nil, fmt.Errorf("job title is required")
+	}
+
+	// Company (required)
+	company := mapping.column(record, "company")
+	if company == "" {
+		return nil, fmt.Errorf("company is required")
+	}
+
+	job := &models.JobApplication{
+		DateApplied: dateApplied,
+		JobTitle:    jobTitle,
+		Company:     company,
+	}
+	if len(statuses) > 0 {
+		job.Status = statuses[0] // Default status
+	}
+
+	// Status (optional)
+	if status := mapping.column(record, "status"); status != "" {
+		job.Status = ""
+		for _, name := range statuses {
+			if strings.EqualFold(name, status) {
+				job.Status = name
+			}
+		}
+		if job.Status == "" {
+			return nil, fmt.Errorf("unknown status '%s'; add it under Settings first", status)
+		}
+	}
+
+	// Job URL and notes (optional)
+	job.JobURL = mapping.column(record, "job_url")
+	job.Notes = mapping.column(record, "notes")
+	job.Tags = models.ParseTags(mapping.column(record, "tags"))
+
+	// Compensation and location (optional)
+	if job.SalaryMin, err = parseSalary(mapping.column(record, "salary_min")); err != nil {
+		return nil, err
+	}
+	if job.SalaryMax, err = parseSalary(mapping.column(record, "salary_max")); err != nil {
+		return nil, err
+	}
+	job.SalaryCurrency = strings.ToUpper(mapping.column(record, "salary_currency"))
+	job.Equity = mapping.column(record, "equity")
+	job.Location = mapping.column(record, "location")
+
+	mode, ok := models.ParseWorkMode(mapping.column(record, "work_mode"))
+	if !ok {
+		return nil, fmt.Errorf("unknown work mode '%s'; use %s", mapping.column(record, "work_mode"), strings.Join(models.GetWorkModes(), ", "))
+	}
+	job.WorkMode = mode
+
+	if _, err := job.ValidateCompensation(); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// parseDate attempts to parse various date formats
+func parseDate(dateStr string) (time.Time, error) {
+	if dateStr == "" {
+		return time.Time{}, fmt.Errorf("date is required")
+	}
+
+	// Try common date formats
+	formats := []string{
+		"2006-01-02",      // ISO format (YYYY-MM-DD)
+		"01/02/2006",      // US format (MM/DD/YYYY)
+		"1/2/2006",        // US format without leading zeros
+		"02/01/2006",      // Some other format (DD/MM/YYYY)
+		"2/1/2006",        // Without leading zeros
+		"2006/01/02",      // YYYY/MM/DD
+		"2006/1/2",        // YYYY/M/D
+		"Jan 2, 2006",     // Month name format
+		"January 2, 2006", // Full month name
+		"2 Jan 2006",      // European style
+		"2006-1-2",        // ISO without leading zeros
+		"Jan 2 2006",      // Month name without comma
+		"January 2 2006",  // Full month name without comma
+		"Feb 2 2006",      // Short month name without comma
+		"Feb 20 2006",     // Short month name without comma (actual example)
+		"March 2 2006",    // Month name variations
+		"Apr 2 2006",
+		"May 2 2006",
+		"Jun 2 2006",
+		"Jul 2 2006",
+		"Aug 2 2006",
+		"Sep 2 2006",
+		"Oct 2 2006",
+		"Nov 2 2006",
+		"Dec 2 2006",
+	}
+
+	for _, format := range formats {
+		if date, err := time.Parse(format, dateStr); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date format")
+}
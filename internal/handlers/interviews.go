@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// dateTimeLocalFormat is the value format of <input type="datetime-local">
+const dateTimeLocalFormat = "2006-01-02T15:04"
+
+// defaultInterviewMinutes is used when no duration is given
+const defaultInterviewMinutes = 60
+
+// upcomingInterviewLimit is the number of interviews shown on the dashboard
+const upcomingInterviewLimit = 5
+
+// interviewRequest is the JSON body accepted when creating or updating an interview.
+// Fields are pointers so PATCH requests can tell omitted fields from empty ones.
+type interviewRequest struct {
+	Round           *string `json:"round"`
+	ScheduledAt     *string `json:"scheduled_at"`
+	DurationMinutes *int    `json:"duration_minutes"`
+	Location        *string `json:"location"`
+	Interviewers    *string `json:"interviewers"`
+	Outcome         *string `json:"outcome"`
+	Feedback        *string `json:"feedback"`
+}
+
+// apply copies the fields present in the request onto interview.
+// It returns the name of the invalid field along with the error.
+func (req *interviewRequest) apply(interview *models.Interview) (string, error) {
+	if req.Round != nil {
+		interview.Round = strings.TrimSpace(*req.Round)
+	}
+	if req.ScheduledAt != nil {
+		scheduledAt, err := time.Parse(time.RFC3339, strings.TrimSpace(*req.ScheduledAt))
+		if err != nil {
+			return "scheduled_at", fmt.Errorf("invalid scheduled_at %q (expected RFC 3339, e.g. 2024-03-01T14:00:00Z)", *req.ScheduledAt)
+		}
+		interview.ScheduledAt = scheduledAt
+	}
+	if req.DurationMinutes != nil {
+		interview.DurationMinutes = *req.DurationMinutes
+	}
+	if req.Location != nil {
+		interview.Location = strings.TrimSpace(*req.Location)
+	}
+	if req.Interviewers != nil {
+		interview.Interviewers = strings.TrimSpace(*req.Interviewers)
+	}
+	if req.Outcome != nil {
+		interview.Outcome = strings.TrimSpace(*req.Outcome)
+	}
+	if req.Feedback != nil {
+		interview.Feedback = *req.Feedback
+	}
+
+	return validateInterview(interview)
+}
+
+// validateInterview fills defaults and checks the fields of an interview.
+// It returns the name of the invalid field along with the error.
+func validateInterview(interview *models.Interview) (string, error) {
+	if interview.Round == "" {
+		return "round", errors.New("round is required")
+	}
+	if interview.ScheduledAt.IsZero() {
+		return "scheduled_at", errors.New("scheduled_at is required")
+	}
+	if interview.DurationMinutes == 0 {
+		interview.DurationMinutes = defaultInterviewMinutes
+	}
+	if interview.DurationMinutes < 0 {
+		return "duration_minutes", errors.New("duration_minutes must be positive")
+	}
+	if interview.Outcome == "" {
+		interview.Outcome = models.OutcomeScheduled
+	}
+	if !models.IsValidInterviewOutcome(interview.Outcome) {
+		return "outcome", fmt.Errorf("outcome must be one of %s", strings.Join(models.GetInterviewOutcomes(), ", "))
+	}
+	return "", nil
+}
+
+// interviewFromForm reads an interview from the edit page form.
+// The scheduled time is entered in the server's local time zone.
+func interviewFromForm(r *http.Request) (*models.Interview, error) {
+	interview := &models.Interview{
+		Round:        strings.TrimSpace(r.FormValue("round")),
+		Location:     strings.TrimSpace(r.FormValue("location")),
+		Interviewers: strings.TrimSpace(r.FormValue("interviewers")),
+		Outcome:      r.FormValue("outcome"),
+		Feedback:     r.FormValue("feedback"),
+	}
+
+	scheduledAt, err := time.ParseInLocation(dateTimeLocalFormat, r.FormValue("scheduled_at"), time.Local)
+	if err != nil {
+		return nil, errors.New("invalid date and time")
+	}
+	interview.ScheduledAt = scheduledAt
+
+	if value := r.FormValue("duration_minutes"); value != "" {
+		interview.DurationMinutes, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("invalid duration")
+		}
+	}
+
+	if _, err := validateInterview(interview); err != nil {
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+// CreateInterviewHandler adds an interview round to a job application from the edit page
+func (h *Handler) CreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	interview, err := interviewFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	interview.JobApplicationID = jobID
+
+	if err := h.store(r).CreateInterview(r.Context(), interview); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			http.Error(w, "Job application not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error creating interview: %v", err)
+		http.Error(w, "Failed to create interview", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#interviews", jobID), http.StatusSeeOther)
+}
+
+// UpdateInterviewHandler saves changes to an interview from the edit page
+func (h *Handler) UpdateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, interviewID, ok := h.interviewRouteIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	interview, err := interviewFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	interview.ID = interviewID
+
+	if err := h.store(r).UpdateInterview(r.Context(), interview); err != nil {
+		if errors.Is(err, database.ErrInterviewNotFound) {
+			http.Error(w, "Interview not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error updating interview: %v", err)
+		http.Error(w, "Failed to update interview", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#interviews", jobID), http.StatusSeeOther)
+}
+
+// DeleteInterviewHandler deletes an interview from the edit page
+func (h *Handler) DeleteInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, interviewID, ok := h.interviewRouteIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store(r).DeleteInterview(r.Context(), interviewID); err != nil {
+		if errors.Is(err, database.ErrInterviewNotFound) {
+			http.Error(w, "Interview not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error deleting interview: %v", err)
+		http.Error(w, "Failed to delete interview", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/edit/%d#interviews", jobID), http.StatusSeeOther)
+}
+
+// interviewRouteIDs parses the {id} and {interviewID} route variables of the edit page
+// routes and checks that the interview belongs to the application
+func (h *Handler) interviewRouteIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	jobID, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	interviewID, err := routeID(r, "interviewID")
+	if err != nil {
+		http.Error(w, "Invalid interview ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	interview, err := h.store(r).GetInterview(r.Context(), interviewID)
+	if err != nil {
+		if errors.Is(err, database.ErrInterviewNotFound) {
+			http.Error(w, "Interview not found", http.StatusNotFound)
+			return 0, 0, false
+		}
+		log.Printf("Error getting interview: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return 0, 0, false
+	}
+	if interview.JobApplicationID != jobID {
+		http.Error(w, "Interview not found", http.StatusNotFound)
+		return 0, 0, false
+	}
+
+	return jobID, interviewID, true
+}
+
+// APIListJobInterviewsHandler returns a job application's interviews as JSON
+func (h *Handler) APIListJobInterviewsHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.store(r).GetJobApplication(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", jobID))
+			return
+		}
+		log.Printf("Error getting job application: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to get job application")
+		return
+	}
+
+	interviews := job.Interviews
+	if interviews == nil {
+		interviews = []*models.Interview{}
+	}
+
+	writeJSON(w, http.StatusOK, interviews)
+}
+
+// APICreateInterviewHandler adds an interview to a job application from a JSON body
+func (h *Handler) APICreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := apiJobID(w, r)
+	if !ok {
+		return
+	}
+
+	var req interviewRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	interview := &models.Interview{JobApplicationID: jobID}
+	if field, err := req.apply(interview); err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_field", err.Error(), field)
+		return
+	}
+
+	if err := h.store(r).CreateInterview(r.Context(), interview); err != nil {
+		if errors.Is(err, database.ErrJobNotFound) {
+			writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Job application with ID %d not found", jobID))
+			return
+		}
+		log.Printf("Error creating interview: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to create interview")
+		return
+	}
+
+	created, err := h.store(r).GetInterview(r.Context(), interview.ID)
+	if err != nil {
+		log.Printf("Error getting created interview: %v", err)
+		created = interview
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/interviews/%d", interview.ID))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// APIGetInterviewHandler returns a single interview as JSON
+func (h *Handler) APIGetInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "interview")
+	if !ok {
+		return
+	}
+
+	interview, err := h.store(r).GetInterview(r.Context(), id)
+	if err != nil {
+		writeInterviewError(w, err, id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, interview)
+}
+
+// APIUpdateInterviewHandler replaces (PUT) or partially updates (PATCH) an interview
+func (h *Handler) APIUpdateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "interview")
+	if !ok {
+		return
+	}
+
+	var req interviewRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+
+	interview, err := h.store(r).GetInterview(r.Context(), id)
+	if err != nil {
+		writeInterviewError(w, err, id)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		interview = &models.Interview{ID: id, JobApplicationID: interview.JobApplicationID}
+	}
+
+	if field, err := req.apply(interview); err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_field", err.Error(), field)
+		return
+	}
+
+	if err := h.store(r).UpdateInterview(r.Context(), interview); err != nil {
+		writeInterviewError(w, err, id)
+		return
+	}
+
+	updated, err := h.store(r).GetInterview(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting updated interview: %v", err)
+		updated = interview
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// APIDeleteInterviewHandler deletes an interview
+func (h *Handler) APIDeleteInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := apiRouteID(w, r, "id", "interview")
+	if !ok {
+		return
+	}
+
+	if err := h.store(r).DeleteInterview(r.Context(), id); err != nil {
+		writeInterviewError(w, err, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeInterviewError maps an interview database error to an API error response
+func writeInterviewError(w http.ResponseWriter, err error, id int) {
+	if errors.Is(err, database.ErrInterviewNotFound) {
+		writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Interview with ID %d not found", id))
+		return
+	}
+	log.Printf("Error accessing interview: %v", err)
+	writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to access interview")
+}
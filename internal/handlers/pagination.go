@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"hunter-seeker/internal/database"
+)
+
+const (
+	defaultPerPage = 25
+	maxPerPage     = 100
+	// pageWindow is how many page links are shown either side of the current page
+	pageWindow = 2
+)
+
+// sortOption is a choice in the dashboard sort menu
+type sortOption struct {
+	Key   string
+	Label string
+}
+
+var sortOptions = []sortOption{
+	{Key: database.SortDate, Label: "Date applied"},
+	{Key: database.SortCompany, Label: "Company"},
+	{Key: database.SortStatus, Label: "Status"},
+	{Key: database.SortUpdated, Label: "Last updated"},
+	{Key: database.SortSalary, Label: "Salary"},
+}
+
+// defaultDescending reports the natural direction of a sort key:
+// newest first for dates, highest first for salaries, alphabetical for text
+func defaultDescending(sort string) bool {
+	return sort == database.SortDate || sort == database.SortUpdated || sort == database.SortSalary
+}
+
+// pageLink is a numbered link in the page navigation. A zero Number marks a gap.
+type pageLink struct {
+	Number  int
+	URL     string
+	Current bool
+}
+
+// filterField is a hidden form field carrying a list filter, so forms such as the sort
+// menu keep the current filters
+type filterField struct {
+	Name  string
+	Value string
+}
+
+// pagination describes the current page of a list and links to its neighbours
+type pagination struct {
+	Page       int
+	PerPage    int
+	TotalPages int
+	Total      int
+	First      int
+	Last       int
+	Path       string
+	Sort       string
+	Order      string
+	PrevURL    string
+	NextURL    string
+	Pages      []pageLink
+	Options    []sortOption
+	// FilterQuery holds the list filters, for links such as the CSV export
+	FilterQuery string
+	// Filters holds the same filters as hidden form fields
+	Filters []filterField
+}
+
+// parseListOptions reads the list filters along with ?sort=, ?order=, ?page= and ?per_page=
+// from the request. Unknown or out of range values fall back to the defaults.
+func parseListOptions(r *http.Request, status string) (database.ListOptions, int) {
+	query := r.URL.Query()
+
+	sort := query.Get("sort")
+	valid := false
+	for _, option := range sortOptions {
+		if option.Key == sort {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		sort = database.SortDate
+	}
+
+	descending := defaultDescending(sort)
+	switch query.Get("order") {
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	}
+
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	opts := listFilters(query, status)
+	opts.Sort = sort
+	opts.Descending = descending
+	opts.Limit = perPage
+	opts.Offset = (page - 1) * perPage
+	return opts, page
+}
+
+// newPagination builds page navigation for a list, keeping the filter and sort in every link
+func newPagination(path string, opts database.ListOptions, page, total int) *pagination {
+	totalPages := (total + opts.Limit - 1) / opts.Limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	order := "asc"
+	if opts.Descending {
+		order = "desc"
+	}
+
+	p := &pagination{
+		Page:       page,
+		PerPage:    opts.Limit,
+		TotalPages: totalPages,
+		Total:      total,
+		Sort:       opts.Sort,
+		Path:       path,
+		Order:      order,
+		Options:    sortOptions,
+	}
+	if filter := filterValues(opts); len(filter) > 0 {
+		p.FilterQuery = "?" + filter.Encode()
+		for _, name := range []string{"status", "tag", "match", "work_mode", "location", "min_salary"} {
+			for _, value := range filter[name] {
+				p.Filters = append(p.Filters, filterField{Name: name, Value: value})
+			}
+		}
+	}
+
+	if total > 0 && opts.Offset < total {
+		p.First = opts.Offset + 1
+		p.Last = opts.Offset + opts.Limit
+		if p.Last > total {
+			p.Last = total
+		}
+	}
+
+	link := func(n int) string {
+		values := filterValues(opts)
+		if opts.Sort != database.SortDate {
+			values.Set("sort", opts.Sort)
+		}
+		if opts.Descending != defaultDescending(opts.Sort) {
+			values.Set("order", order)
+		}
+		if opts.Limit != defaultPerPage {
+			values.Set("per_page", strconv.Itoa(opts.Limit))
+		}
+		if n > 1 {
+			values.Set("page", strconv.Itoa(n))
+		}
+		if len(values) == 0 {
+			return path
+		}
+		return path + "?" + values.Encode()
+	}
+
+	if page > 1 {
+		p.PrevURL = link(page - 1)
+	}
+	if page < totalPages {
+		p.NextURL = link(page + 1)
+	}
+
+	for n := 1; n <= totalPages; n++ {
+		if n == 1 || n == totalPages || (n >= page-pageWindow && n <= page+pageWindow) {
+			p.Pages = append(p.Pages, pageLink{Number: n, URL: link(n), Current: n == page})
+		} else if len(p.Pages) > 0 && p.Pages[len(p.Pages)-1].Number != 0 {
+			p.Pages = append(p.Pages, pageLink{})
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+// highlightHTML escapes a search highlight and wraps matched terms in <mark>
+func highlightHTML(s string) template.HTML {
+	escaped := template.HTMLEscapeString(s)
+	escaped = strings.ReplaceAll(escaped, models.HighlightStart, "<mark>")
+	escaped = strings.ReplaceAll(escaped, models.HighlightEnd, "</mark>")
+	return template.HTML(escaped)
+}
+
+// SearchHandler renders full-text search results, optionally filtered by ?status=
+func (h *Handler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	status := r.URL.Query().Get("status")
+
+	results, err := h.store(r).SearchJobApplications(r.Context(), query, status)
+	if err != nil {
+		log.Printf("Error searching job applications: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	statuses := h.workflow(r.Context())
+
+	data := struct {
+		Query         string
+		Results       []models.SearchResult
+		Statuses      []string
+		StatusColors  map[string]string
+		CurrentFilter string
+	}{
+		Query:         query,
+		Results:       results,
+		Statuses:      statusNames(statuses),
+		StatusColors:  statusColors(statuses),
+		CurrentFilter: status,
+	}
+
+	if err := h.render(w, r, "search.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// hexColor matches the colors produced by <input type="color">
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// workflow loads the configured statuses, falling back to the default workflow
+// so pages still render if they cannot be read
+func (h *Handler) workflow(ctx context.Context) []*models.Status {
+	statuses, err := h.db.GetStatuses(ctx)
+	if err == nil {
+		return statuses
+	}
+
+	log.Printf("Error getting statuses: %v", err)
+	var defaults []*models.Status
+	for i, status := range models.GetDefaultStatuses() {
+		defaults = append(defaults, &models.Status{Name: status.Name, Position: i + 1, Color: status.Color, Category: status.Category})
+	}
+	return defaults
+}
+
+// statusNames returns the names of statuses in order
+func statusNames(statuses []*models.Status) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = status.Name
+	}
+	return names
+}
+
+// noResponseStatus returns the name of the status with the no-response role, or ""
+func noResponseStatus(statuses []*models.Status) string {
+	for _, status := range statuses {
+		if status.Role == models.RoleNoResponse {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// statusColors maps status names to their colors for templates
+func statusColors(statuses []*models.Status) map[string]string {
+	colors := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		colors[status.Name] = status.Color
+	}
+	return colors
+}
+
+// allowedStatuses returns the names of the statuses an application in current may
+// move to, including current itself, in workflow order
+func allowedStatuses(statuses []*models.Status, current string) []string {
+	var from *models.Status
+	for _, status := range statuses {
+		if status.Name == current {
+			from = status
+		}
+	}
+
+	var names []string
+	for _, status := range statuses {
+		if from == nil || from.CanTransitionTo(status.Name) {
+			names = append(names, status.Name)
+		}
+	}
+	if from == nil && current != "" {
+		// Keep a status that has since been removed selectable
+		names = append([]string{current}, names...)
+	}
+	return names
+}
+
+// statusRoleOption is a choice of role in the status settings form
+type statusRoleOption struct {
+	Value string
+	Label string
+}
+
+// statusRoleOptions lists the roles a status can have, starting with none
+var statusRoleOptions = []statusRoleOption{
+	{"", "none"},
+	{models.RoleRejection, "rejection"},
+	{models.RoleNoResponse, "no response"},
+}
+
+// statusFromForm reads a status's name, color, category and role from the settings form
+func statusFromForm(r *http.Request) (*models.Status, error) {
+	status := &models.Status{
+		Name:     strings.TrimSpace(r.FormValue("name")),
+		Color:    strings.ToLower(r.FormValue("color")),
+		Category: r.FormValue("category"),
+		Role:     r.FormValue("role"),
+	}
+
+	if status.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if !hexColor.MatchString(status.Color) {
+		return nil, errors.New("color must be a hex color such as #3498db")
+	}
+	if !models.IsValidStatusCategory(status.Category) {
+		return nil, fmt.Errorf("category must be one of %s", strings.Join(models.GetStatusCategories(), ", "))
+	}
+	if !models.IsValidStatusRole(status.Role) {
+		return nil, fmt.Errorf("role must be empty or one of %s", strings.Join(models.GetStatusRoles(), ", "))
+	}
+
+	return status, nil
+}
+
+// redirectToStatusSettings returns to the settings page with a success or error message
+func redirectToStatusSettings(w http.ResponseWriter, r *http.Request, kind, message string) {
+	http.Redirect(w, r, "/settings/statuses?"+url.Values{kind: {message}}.Encode(), http.StatusSeeOther)
+}
+
+// StatusSettingsHandler renders the status workflow settings page
+func (h *Handler) StatusSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.store(r).GetStatuses(r.Context())
+	if err != nil {
+		log.Printf("Error getting statuses: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	statusMessage, statusType := r.URL.Query().Get("success"), "success"
+	if message := r.URL.Query().Get("error"); message != "" {
+		statusMessage, statusType = message, "error"
+	}
+
+	data := struct {
+		Statuses      []*models.Status
+		Categories    []string
+		Roles         []statusRoleOption
+		StatusMessage string
+		StatusType    string
+	}{
+		Statuses:      statuses,
+		Categories:    models.GetStatusCategories(),
+		Roles:         statusRoleOptions,
+		StatusMessage: statusMessage,
+		StatusType:    statusType,
+	}
+
+	if err := h.render(w, r, "statuses.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// CreateStatusHandler adds a status to the end of the workflow
+func (h *Handler) CreateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	status, err := statusFromForm(r)
+	if err != nil {
+		redirectToStatusSettings(w, r, "error", err.Error())
+		return
+	}
+
+	if err := h.db.CreateStatus(r.Context(), status); err != nil {
+		if errors.Is(err, database.ErrStatusNameTaken) {
+			redirectToStatusSettings(w, r, "error", fmt.Sprintf("A status named %q already exists", status.Name))
+			return
+		}
+		log.Printf("Error creating status: %v", err)
+		http.Error(w, "Failed to create status", http.StatusInternalServerError)
+		return
+	}
+
+	redirectToStatusSettings(w, r, "success", fmt.Sprintf("Added %s", status.Name))
+}
+
+// UpdateStatusHandler saves a status's details and allowed transitions
+func (h *Handler) UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid status ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	status, err := statusFromForm(r)
+	if err != nil {
+		redirectToStatusSettings(w, r, "error", err.Error())
+		return
+	}
+	status.ID = id
+
+	var transitions []int
+	for _, value := range r.Form["transitions"] {
+		toID, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid transition", http.StatusBadRequest)
+			return
+		}
+		transitions = append(transitions, toID)
+	}
+
+	if err := h.db.UpdateStatus(r.Context(), status); err != nil {
+		switch {
+		case errors.Is(err, database.ErrStatusNotFound):
+			http.Error(w, "Status not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrStatusNameTaken):
+			redirectToStatusSettings(w, r, "error", fmt.Sprintf("A status named %q already exists", status.Name))
+		default:
+			log.Printf("Error updating status: %v", err)
+			http.Error(w, "Failed to update status", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := h.db.SetStatusTransitions(r.Context(), id, transitions); err != nil {
+		log.Printf("Error saving status transitions: %v", err)
+		http.Error(w, "Failed to save status transitions", http.StatusInternalServerError)
+		return
+	}
+
+	redirectToStatusSettings(w, r, "success", fmt.Sprintf("Saved %s", status.Name))
+}
+
+// MoveStatusHandler moves a status one place up or down the workflow
+func (h *Handler) MoveStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid status ID", http.StatusBadRequest)
+		return
+	}
+
+	offset := 1
+	if r.FormValue("direction") == "up" {
+		offset = -1
+	}
+
+	if err := h.db.MoveStatus(r.Context(), id, offset); err != nil {
+		if errors.Is(err, database.ErrStatusNotFound) {
+			http.Error(w, "Status not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error moving status: %v", err)
+		http.Error(w, "Failed to move status", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/settings/statuses", http.StatusSeeOther)
+}
+
+// DeleteStatusHandler deletes a status that no application uses
+func (h *Handler) DeleteStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid status ID", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.store(r).GetStatus(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrStatusNotFound) {
+			http.Error(w, "Status not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting status: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.db.DeleteStatus(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrStatusInUse) {
+			redirectToStatusSettings(w, r, "error", fmt.Sprintf("%s is still used by applications; move them to another status first", status.Name))
+			return
+		}
+		log.Printf("Error deleting status: %v", err)
+		http.Error(w, "Failed to delete status", http.StatusInternalServerError)
+		return
+	}
+
+	redirectToStatusSettings(w, r, "success", fmt.Sprintf("Deleted %s", status.Name))
+}
+
+// APIListStatusesHandler returns the configured statuses in workflow order as JSON
+func (h *Handler) APIListStatusesHandler(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.store(r).GetStatuses(r.Context())
+	if err != nil {
+		log.Printf("Error getting statuses: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to list statuses")
+		return
+	}
+
+	for _, status := range statuses {
+		if status.Transitions == nil {
+			status.Transitions = []string{}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, statuses)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// tagFilter is the dashboard's tag filter: the tags to choose from and those selected
+type tagFilter struct {
+	Tags     []*models.Tag
+	Selected []string
+	MatchAll bool
+}
+
+// newTagFilter describes the tag filter of a list request
+func newTagFilter(tags []*models.Tag, opts database.ListOptions) *tagFilter {
+	return &tagFilter{Tags: tags, Selected: opts.Tags, MatchAll: opts.MatchAllTags || len(opts.Tags) == 0}
+}
+
+// Has reports whether the named tag is selected
+func (f *tagFilter) Has(name string) bool {
+	for _, selected := range f.Selected {
+		if strings.EqualFold(selected, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIListTagsHandler returns the tags in use, with how many applications have each, as JSON
+func (h *Handler) APIListTagsHandler(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.store(r).GetTags(r.Context())
+	if err != nil {
+		log.Printf("Error getting tags: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "Failed to list tags")
+		return
+	}
+
+	if tags == nil {
+		tags = []*models.Tag{}
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+}
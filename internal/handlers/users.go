@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"hunter-seeker/internal/auth"
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// redirectToUsers returns to the users page with a success or error message
+func redirectToUsers(w http.ResponseWriter, r *http.Request, kind, message string) {
+	http.Redirect(w, r, "/admin/users?"+url.Values{kind: {message}}.Encode(), http.StatusSeeOther)
+}
+
+// userFromForm reads a user's username and admin flag from the users page
+func userFromForm(r *http.Request) (*models.User, error) {
+	user := &models.User{
+		Username: strings.TrimSpace(r.FormValue("username")),
+		IsAdmin:  r.FormValue("is_admin") == "true",
+	}
+	if err := models.ValidateUsername(user.Username); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// passwordFromForm hashes the password field, or returns "" if it is blank and optional
+func passwordFromForm(r *http.Request, required bool) (string, error) {
+	password := r.FormValue("password")
+	if password == "" && !required {
+		return "", nil
+	}
+	if len(password) < auth.MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", auth.MinPasswordLength)
+	}
+	return auth.HashPassword(password)
+}
+
+// UsersHandler renders the page where admins manage users
+func (h *Handler) UsersHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	users, err := h.db.GetUsers(r.Context())
+	if err != nil {
+		log.Printf("Error getting users: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	statusMessage, statusType := r.URL.Query().Get("success"), "success"
+	if message := r.URL.Query().Get("error"); message != "" {
+		statusMessage, statusType = message, "error"
+	}
+
+	var currentUserID int
+	if user := currentUser(r); user != nil {
+		currentUserID = user.ID
+	}
+
+	data := struct {
+		Users             []*models.User
+		CurrentUserID     int
+		MinPasswordLength int
+		StatusMessage     string
+		StatusType        string
+	}{
+		Users:             users,
+		CurrentUserID:     currentUserID,
+		MinPasswordLength: auth.MinPasswordLength,
+		StatusMessage:     statusMessage,
+		StatusType:        statusType,
+	}
+
+	if err := h.render(w, r, "users.html", data); err != nil {
+		log.Printf("Error executing template: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// CreateUserHandler adds a user with a password
+func (h *Handler) CreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	user, err := userFromForm(r)
+	if err == nil {
+		user.PasswordHash, err = passwordFromForm(r, true)
+	}
+	if err != nil {
+		redirectToUsers(w, r, "error", err.Error())
+		return
+	}
+
+	if err := h.db.CreateUser(r.Context(), user); err != nil {
+		if errors.Is(err, database.ErrUsernameTaken) {
+			redirectToUsers(w, r, "error", fmt.Sprintf("The username %q is taken", user.Username))
+			return
+		}
+		log.Printf("Error creating user: %v", err)
+		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.reloadAuth(r.Context()); err != nil {
+		log.Printf("Error reloading login settings: %v", err)
+	}
+
+	redirectToUsers(w, r, "success", fmt.Sprintf("Added %s", user.Username))
+}
+
+// UpdateUserHandler saves a user's username and admin flag, and their password if a new one is given
+func (h *Handler) UpdateUserHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	user, err := userFromForm(r)
+	var hash string
+	if err == nil {
+		hash, err = passwordFromForm(r, false)
+	}
+	if err != nil {
+		redirectToUsers(w, r, "error", err.Error())
+		return
+	}
+	user.ID = id
+
+	if err := h.db.UpdateUser(r.Context(), user); err != nil {
+		switch {
+		case errors.Is(err, database.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrUsernameTaken):
+			redirectToUsers(w, r, "error", fmt.Sprintf("The username %q is taken", user.Username))
+		case errors.Is(err, database.ErrLastAdmin):
+			redirectToUsers(w, r, "error", fmt.Sprintf("%s is the only admin; make someone else an admin first", user.Username))
+		default:
+			log.Printf("Error updating user: %v", err)
+			http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if hash != "" {
+		if err := h.db.SetUserPassword(r.Context(), id, hash); err != nil {
+			log.Printf("Error saving password: %v", err)
+			http.Error(w, "Failed to save password", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.reloadAuth(r.Context()); err != nil {
+		log.Printf("Error reloading login settings: %v", err)
+	}
+
+	redirectToUsers(w, r, "success", fmt.Sprintf("Saved %s", user.Username))
+}
+
+// DeleteUserHandler deletes another user along with all of their data
+func (h *Handler) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+
+	id, err := routeID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if user := currentUser(r); user != nil && user.ID == id {
+		redirectToUsers(w, r, "error", "You cannot delete your own account")
+		return
+	}
+
+	user, err := h.db.GetUser(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, database.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting user: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.db.DeleteUser(r.Context(), id); err != nil {
+		if errors.Is(err, database.ErrLastAdmin) {
+			redirectToUsers(w, r, "error", fmt.Sprintf("%s is the only admin and cannot be deleted", user.Username))
+			return
+		}
+		log.Printf("Error deleting user: %v", err)
+		http.Error(w, "Failed to delete user", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.reloadAuth(r.Context()); err != nil {
+		log.Printf("Error reloading login settings: %v", err)
+	}
+
+	redirectToUsers(w, r, "success", fmt.Sprintf("Deleted %s", user.Username))
+}
@@ -0,0 +1,154 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+type attachmentRecord struct {
+	models.Attachment
+	content []byte
+}
+
+// attachmentFile is an attachment's content opened for reading
+type attachmentFile struct {
+	*bytes.Reader
+}
+
+// Close does nothing; the content stays in memory
+func (attachmentFile) Close() error {
+	return nil
+}
+
+// ownAttachment returns an attachment of one of the user's applications, or nil
+func (d *data) ownAttachment(userID, id int) *attachmentRecord {
+	record, ok := d.attachments[id]
+	if !ok || d.ownJob(userID, record.JobApplicationID) == nil {
+		return nil
+	}
+	return record
+}
+
+// CreateAttachment keeps an uploaded file's content and records it against its job
+// application. The file's size is set from the content read.
+func (s *Store) CreateAttachment(ctx context.Context, attachment *models.Attachment, content io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Read before taking the lock, as an upload may be slow
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to write attachment file: %w", err)
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, attachment.JobApplicationID) == nil {
+		return database.ErrJobNotFound
+	}
+
+	record := &attachmentRecord{Attachment: *attachment, content: data}
+	record.ID = d.nextID("attachments")
+	record.Size = int64(len(data))
+	record.StoredName = strconv.Itoa(record.ID) + strings.ToLower(filepath.Ext(attachment.Filename))
+	record.CreatedAt = timestamp()
+	d.attachments[record.ID] = record
+
+	attachment.ID = record.ID
+	attachment.Size = record.Size
+	attachment.StoredName = record.StoredName
+	attachment.CreatedAt = record.CreatedAt
+	return nil
+}
+
+// GetAttachment retrieves an attachment by ID
+func (s *Store) GetAttachment(ctx context.Context, id int) (*models.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := d.ownAttachment(s.userID, id)
+	if record == nil {
+		return nil, database.ErrAttachmentNotFound
+	}
+	attachment := record.Attachment
+	return &attachment, nil
+}
+
+// GetAttachmentsForJob retrieves the attachments of a job application, newest first
+func (s *Store) GetAttachmentsForJob(ctx context.Context, jobID int) ([]*models.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, jobID) == nil {
+		return nil, nil
+	}
+	return d.attachmentsForJob(jobID), nil
+}
+
+func (d *data) attachmentsForJob(jobID int) []*models.Attachment {
+	var attachments []*models.Attachment
+	for _, record := range d.attachments {
+		if record.JobApplicationID == jobID {
+			attachment := record.Attachment
+			attachments = append(attachments, &attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		a, b := attachments[i], attachments[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	return attachments
+}
+
+// OpenAttachment opens an attachment's content for reading. The caller must close it.
+func (s *Store) OpenAttachment(attachment *models.Attachment) (io.ReadSeekCloser, error) {
+	d, unlock := s.lock()
+	defer unlock()
+
+	for _, record := range d.attachments {
+		if record.StoredName == attachment.StoredName {
+			return attachmentFile{bytes.NewReader(record.content)}, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to open attachment file: %w", os.ErrNotExist)
+}
+
+// DeleteAttachment deletes an attachment and its content
+func (s *Store) DeleteAttachment(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownAttachment(s.userID, id) == nil {
+		return database.ErrAttachmentNotFound
+	}
+
+	delete(d.attachments, id)
+	return nil
+}
@@ -0,0 +1,311 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+type companyRecord struct {
+	userID     int
+	normalized string
+	company    models.Company
+}
+
+type aliasRecord struct {
+	userID     int
+	normalized string
+	alias      models.CompanyAlias
+}
+
+// findCompanyID returns the user's company a normalized name resolves to, by name or alias.
+// It returns 0 if no company matches.
+func (d *data) findCompanyID(userID int, normalized string) int {
+	for id, record := range d.companies {
+		if record.userID == userID && record.normalized == normalized {
+			return id
+		}
+	}
+	for _, record := range d.aliases {
+		if record.userID == userID && record.normalized == normalized {
+			return record.alias.CompanyID
+		}
+	}
+	return 0
+}
+
+// resolveCompanyID finds the user's company a job's company name refers to, creating
+// it if this is the first application there. Blank names are not linked.
+func (d *data) resolveCompanyID(userID int, name string) int {
+	normalized := models.NormalizeCompanyName(name)
+	if normalized == "" {
+		return 0
+	}
+
+	if id := d.findCompanyID(userID, normalized); id != 0 {
+		return id
+	}
+
+	now := timestamp()
+	record := &companyRecord{
+		userID:     userID,
+		normalized: normalized,
+		company:    models.Company{ID: d.nextID("companies"), Name: strings.TrimSpace(name), CreatedAt: now, UpdatedAt: now},
+	}
+	d.companies[record.company.ID] = record
+	return record.company.ID
+}
+
+// ownCompany returns the user's company, or nil
+func (d *data) ownCompany(userID, id int) *companyRecord {
+	record, ok := d.companies[id]
+	if !ok || record.userID != userID {
+		return nil
+	}
+	return record
+}
+
+// companyWithCounts returns a copy of a company with its application and rejection counts
+func (d *data) companyWithCounts(record *companyRecord) *models.Company {
+	company := record.company
+	for _, job := range d.jobs {
+		if job.job.CompanyID == company.ID {
+			company.ApplicationCount++
+			if status := d.findStatus(job.job.Status); status != nil && status.Role == models.RoleRejection {
+				company.RejectionCount++
+			}
+		}
+	}
+	return &company
+}
+
+// GetAllCompanies retrieves every company with its application counts, ordered by name
+func (s *Store) GetAllCompanies(ctx context.Context) ([]*models.Company, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	var companies []*models.Company
+	for _, record := range d.companies {
+		if record.userID == s.userID {
+			companies = append(companies, d.companyWithCounts(record))
+		}
+	}
+	sort.Slice(companies, func(i, j int) bool {
+		a, b := strings.ToLower(companies[i].Name), strings.ToLower(companies[j].Name)
+		if a != b {
+			return a < b
+		}
+		return companies[i].ID < companies[j].ID
+	})
+	return companies, nil
+}
+
+// GetCompany retrieves a company with its aliases and applications
+func (s *Store) GetCompany(ctx context.Context, id int) (*models.Company, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.getCompany(s.userID, id)
+}
+
+func (d *data) getCompany(userID, id int) (*models.Company, error) {
+	record := d.ownCompany(userID, id)
+	if record == nil {
+		return nil, database.ErrCompanyNotFound
+	}
+
+	company := d.companyWithCounts(record)
+	for _, alias := range d.aliases {
+		if alias.alias.CompanyID == id {
+			company.Aliases = append(company.Aliases, alias.alias)
+		}
+	}
+	sort.Slice(company.Aliases, func(i, j int) bool {
+		a, b := strings.ToLower(company.Aliases[i].Alias), strings.ToLower(company.Aliases[j].Alias)
+		if a != b {
+			return a < b
+		}
+		return company.Aliases[i].ID < company.Aliases[j].ID
+	})
+
+	company.Applications = d.userJobs(userID, func(job *models.JobApplication) bool { return job.CompanyID == id })
+	sortNewestFirst(company.Applications)
+	return company, nil
+}
+
+// FindCompanyByName returns the company a name would be linked to, without creating one
+func (s *Store) FindCompanyByName(ctx context.Context, name string) (*models.Company, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	normalized := models.NormalizeCompanyName(name)
+	if normalized == "" {
+		return nil, database.ErrCompanyNotFound
+	}
+
+	id := d.findCompanyID(s.userID, normalized)
+	if id == 0 {
+		return nil, database.ErrCompanyNotFound
+	}
+	return d.getCompany(s.userID, id)
+}
+
+// UpdateCompany updates a company's name and details. When the name changes to
+// a different normalized form the old name is kept as an alias, so applications
+// using it stay linked.
+func (s *Store) UpdateCompany(ctx context.Context, company *models.Company) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	normalized := models.NormalizeCompanyName(company.Name)
+	if normalized == "" {
+		return database.ErrInvalidAlias
+	}
+
+	record := d.ownCompany(s.userID, company.ID)
+	if record == nil {
+		return database.ErrCompanyNotFound
+	}
+
+	if normalized != record.normalized {
+		if existing := d.findCompanyID(s.userID, normalized); existing != 0 && existing != company.ID {
+			return database.ErrCompanyNameTaken
+		}
+
+		for id, alias := range d.aliases {
+			if alias.userID == s.userID && alias.normalized == normalized {
+				delete(d.aliases, id)
+			}
+		}
+		d.insertCompanyAlias(s.userID, company.ID, record.company.Name, record.normalized)
+	}
+
+	record.normalized = normalized
+	record.company.Name = strings.TrimSpace(company.Name)
+	record.company.Size = company.Size
+	record.company.Industry = company.Industry
+	record.company.Website = company.Website
+	record.company.Notes = company.Notes
+	record.company.UpdatedAt = timestamp()
+	return nil
+}
+
+// AddCompanyAlias records another name for a company. If the alias already
+// resolves to a different company, that company is merged into this one:
+// its applications and aliases move here and blank details are filled from it.
+func (s *Store) AddCompanyAlias(ctx context.Context, companyID int, alias string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	normalized := models.NormalizeCompanyName(alias)
+	if normalized == "" {
+		return database.ErrInvalidAlias
+	}
+
+	record := d.ownCompany(s.userID, companyID)
+	if record == nil {
+		return database.ErrCompanyNotFound
+	}
+	if normalized == record.normalized {
+		return nil
+	}
+
+	if otherID := d.findCompanyID(s.userID, normalized); otherID != 0 && otherID != companyID {
+		d.mergeCompany(s.userID, companyID, otherID)
+	}
+
+	d.insertCompanyAlias(s.userID, companyID, alias, normalized)
+	return nil
+}
+
+// RemoveCompanyAlias deletes one of a company's aliases. Applications already
+// linked keep their company until their company name is edited.
+func (s *Store) RemoveCompanyAlias(ctx context.Context, companyID, aliasID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	alias, ok := d.aliases[aliasID]
+	if !ok || alias.alias.CompanyID != companyID || alias.userID != s.userID {
+		return database.ErrCompanyNotFound
+	}
+
+	delete(d.aliases, aliasID)
+	return nil
+}
+
+// insertCompanyAlias adds an alias, or points the user's existing alias with the same
+// normalized form at the company
+func (d *data) insertCompanyAlias(userID, companyID int, alias, normalized string) {
+	for _, record := range d.aliases {
+		if record.userID == userID && record.normalized == normalized {
+			record.alias.CompanyID = companyID
+			record.alias.Alias = strings.TrimSpace(alias)
+			return
+		}
+	}
+
+	record := &aliasRecord{
+		userID:     userID,
+		normalized: normalized,
+		alias:      models.CompanyAlias{ID: d.nextID("aliases"), CompanyID: companyID, Alias: strings.TrimSpace(alias)},
+	}
+	d.aliases[record.alias.ID] = record
+}
+
+// mergeCompany moves everything belonging to the user's company from into company into, then deletes from
+func (d *data) mergeCompany(userID, into, from int) {
+	source := d.companies[from]
+	target := d.companies[into]
+
+	for _, job := range d.jobs {
+		if job.job.CompanyID == from {
+			job.job.CompanyID = into
+		}
+	}
+	for _, alias := range d.aliases {
+		if alias.alias.CompanyID == from {
+			alias.alias.CompanyID = into
+		}
+	}
+
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&target.company.Size, source.company.Size)
+	fill(&target.company.Industry, source.company.Industry)
+	fill(&target.company.Website, source.company.Website)
+	fill(&target.company.Notes, source.company.Notes)
+	target.company.UpdatedAt = timestamp()
+
+	delete(d.companies, from)
+	d.insertCompanyAlias(userID, into, source.company.Name, source.normalized)
+}
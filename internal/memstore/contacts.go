@@ -0,0 +1,232 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+type contactRecord struct {
+	userID  int
+	contact models.Contact
+}
+
+// sortContacts orders contacts by name, ignoring case
+func sortContacts(contacts []*models.Contact) {
+	sort.Slice(contacts, func(i, j int) bool {
+		a, b := strings.ToLower(contacts[i].Name), strings.ToLower(contacts[j].Name)
+		if a != b {
+			return a < b
+		}
+		return contacts[i].ID < contacts[j].ID
+	})
+}
+
+// ownContact returns the user's contact, or nil
+func (d *data) ownContact(userID, id int) *contactRecord {
+	record, ok := d.contacts[id]
+	if !ok || record.userID != userID {
+		return nil
+	}
+	return record
+}
+
+// CreateContact inserts a new contact
+func (s *Store) CreateContact(ctx context.Context, contact *models.Contact) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := &contactRecord{userID: s.userID, contact: *contact}
+	record.contact.ID = d.nextID("contacts")
+	record.contact.CreatedAt = timestamp()
+	record.contact.UpdatedAt = record.contact.CreatedAt
+	record.contact.Applications = nil
+	d.contacts[record.contact.ID] = record
+
+	contact.ID = record.contact.ID
+	return nil
+}
+
+// GetContact retrieves a contact by ID along with the applications it is linked to
+func (s *Store) GetContact(ctx context.Context, id int) (*models.Contact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := d.ownContact(s.userID, id)
+	if record == nil {
+		return nil, database.ErrContactNotFound
+	}
+
+	contact := record.contact
+	contact.Applications = d.jobsForContact(s.userID, id)
+	return &contact, nil
+}
+
+// GetAllContacts retrieves all contacts, ordered by name
+func (s *Store) GetAllContacts(ctx context.Context) ([]*models.Contact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	var contacts []*models.Contact
+	for _, record := range d.contacts {
+		if record.userID == s.userID {
+			contact := record.contact
+			contacts = append(contacts, &contact)
+		}
+	}
+	sortContacts(contacts)
+	return contacts, nil
+}
+
+// UpdateContact updates an existing contact
+func (s *Store) UpdateContact(ctx context.Context, contact *models.Contact) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := d.ownContact(s.userID, contact.ID)
+	if record == nil {
+		return database.ErrContactNotFound
+	}
+
+	record.contact.Name = contact.Name
+	record.contact.Role = contact.Role
+	record.contact.Email = contact.Email
+	record.contact.Phone = contact.Phone
+	record.contact.LinkedInURL = contact.LinkedInURL
+	record.contact.Notes = contact.Notes
+	record.contact.UpdatedAt = timestamp()
+	return nil
+}
+
+// DeleteContact deletes a contact and its links to applications
+func (s *Store) DeleteContact(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownContact(s.userID, id) == nil {
+		return database.ErrContactNotFound
+	}
+
+	d.deleteContact(id)
+	return nil
+}
+
+func (d *data) deleteContact(id int) {
+	delete(d.contacts, id)
+	for _, contactIDs := range d.jobContacts {
+		delete(contactIDs, id)
+	}
+}
+
+// GetContactsForJob retrieves the contacts linked to a job application, ordered by name
+func (s *Store) GetContactsForJob(ctx context.Context, jobID int) ([]*models.Contact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.contactsForJob(s.userID, jobID), nil
+}
+
+func (d *data) contactsForJob(userID, jobID int) []*models.Contact {
+	var contacts []*models.Contact
+	for contactID := range d.jobContacts[jobID] {
+		if record := d.ownContact(userID, contactID); record != nil {
+			contact := record.contact
+			contacts = append(contacts, &contact)
+		}
+	}
+	sortContacts(contacts)
+	return contacts
+}
+
+// GetJobApplicationsForContact retrieves the applications a contact is linked to, newest first
+func (s *Store) GetJobApplicationsForContact(ctx context.Context, contactID int) ([]*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.jobsForContact(s.userID, contactID), nil
+}
+
+func (d *data) jobsForContact(userID, contactID int) []*models.JobApplication {
+	jobs := d.userJobs(userID, func(job *models.JobApplication) bool { return d.jobContacts[job.ID][contactID] })
+	sortNewestFirst(jobs)
+	return jobs
+}
+
+// LinkContact links a contact to a job application. Linking twice is not an error.
+func (s *Store) LinkContact(ctx context.Context, jobID, contactID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkJobAndContact(s.userID, jobID, contactID); err != nil {
+		return err
+	}
+
+	if d.jobContacts[jobID] == nil {
+		d.jobContacts[jobID] = make(map[int]bool)
+	}
+	d.jobContacts[jobID][contactID] = true
+	return nil
+}
+
+// UnlinkContact removes the link between a contact and a job application
+func (s *Store) UnlinkContact(ctx context.Context, jobID, contactID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkJobAndContact(s.userID, jobID, contactID); err != nil {
+		return err
+	}
+
+	delete(d.jobContacts[jobID], contactID)
+	return nil
+}
+
+// checkJobAndContact returns ErrJobNotFound or ErrContactNotFound if either is missing
+func (d *data) checkJobAndContact(userID, jobID, contactID int) error {
+	if d.ownJob(userID, jobID) == nil {
+		return database.ErrJobNotFound
+	}
+	if d.ownContact(userID, contactID) == nil {
+		return database.ErrContactNotFound
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/followup"
+	"hunter-seeker/internal/models"
+)
+
+// GetFollowUpRules retrieves all follow-up rules, ordered by status. Rules are shared by every user.
+func (s *Store) GetFollowUpRules(ctx context.Context) ([]*models.FollowUpRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.followUpRules(), nil
+}
+
+func (d *data) followUpRules() []*models.FollowUpRule {
+	var rules []*models.FollowUpRule
+	for _, stored := range d.rules {
+		rule := *stored
+		rules = append(rules, &rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return strings.ToLower(rules[i].Status) < strings.ToLower(rules[j].Status) })
+	return rules
+}
+
+// SaveFollowUpRule creates the rule for a status, or replaces the existing one
+func (s *Store) SaveFollowUpRule(ctx context.Context, rule *models.FollowUpRule) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	for _, stored := range d.rules {
+		if stored.Status == rule.Status {
+			stored.FollowUpDays = rule.FollowUpDays
+			stored.NoResponseDays = rule.NoResponseDays
+			stored.UpdatedAt = timestamp()
+			rule.ID = stored.ID
+			return nil
+		}
+	}
+
+	now := timestamp()
+	stored := &models.FollowUpRule{
+		ID:             d.nextID("rules"),
+		Status:         rule.Status,
+		FollowUpDays:   rule.FollowUpDays,
+		NoResponseDays: rule.NoResponseDays,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	d.rules[stored.ID] = stored
+	rule.ID = stored.ID
+	return nil
+}
+
+// DeleteFollowUpRule deletes a follow-up rule
+func (s *Store) DeleteFollowUpRule(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if _, ok := d.rules[id]; !ok {
+		return database.ErrFollowUpRuleNotFound
+	}
+
+	delete(d.rules, id)
+	return nil
+}
+
+// SetNextActionDate sets or, with nil, clears a job application's follow-up date
+func (s *Store) SetNextActionDate(ctx context.Context, id int, date *time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := d.ownJob(s.userID, id)
+	if record == nil {
+		return database.ErrJobNotFound
+	}
+
+	record.job.NextActionDate = nil
+	if date != nil {
+		value := *date
+		record.job.NextActionDate = &value
+	}
+	record.job.UpdatedAt = timestamp()
+	return nil
+}
+
+// GetApplicationsNeedingAttention returns the applications whose follow-up is due as of now
+func (s *Store) GetApplicationsNeedingAttention(ctx context.Context, now time.Time) ([]followup.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return followup.NeedsAttention(d.allJobs(s.userID), d.followUpRules(), now), nil
+}
+
+// MoveSilentApplications moves applications that have been silent for longer than
+// their rule's no-response period to the status with the no-response role, recording
+// the status change. The rules are shared, so every user's applications are checked.
+// Applications whose status does not allow the move are left alone, and nothing moves
+// if no status has the role. It returns the number of applications moved.
+func (s *Store) MoveSilentApplications(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	noResponse := d.noResponseStatus()
+	if noResponse == "" {
+		return 0, nil
+	}
+
+	rules := d.followUpRules()
+	moved := 0
+	for _, user := range d.orderedUsers() {
+		silent := followup.Silent(d.allJobs(user.ID), rules, noResponse, now)
+		if len(silent) == 0 {
+			continue
+		}
+
+		for _, job := range silent {
+			if err := d.checkTransition(job.Status, noResponse); err != nil {
+				if errors.Is(err, database.ErrTransitionNotAllowed) {
+					continue
+				}
+				return moved, err
+			}
+
+			record := d.jobs[job.ID]
+			record.job.Status = noResponse
+			record.job.UpdatedAt = timestamp()
+			d.recordStatusEvent(job.ID, job.Status, noResponse)
+			moved++
+		}
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,199 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// copyInterview returns a copy of a stored interview in the server's local time zone
+func copyInterview(stored *models.Interview) *models.Interview {
+	interview := *stored
+	interview.ScheduledAt = interview.ScheduledAt.Local()
+	interview.Job = nil
+	return &interview
+}
+
+// sortBySchedule orders interviews in the order they happen
+func sortBySchedule(interviews []*models.Interview) {
+	sort.Slice(interviews, func(i, j int) bool {
+		a, b := interviews[i], interviews[j]
+		if !a.ScheduledAt.Equal(b.ScheduledAt) {
+			return a.ScheduledAt.Before(b.ScheduledAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// ownInterview returns an interview of one of the user's applications, or nil
+func (d *data) ownInterview(userID, id int) *models.Interview {
+	interview, ok := d.interviews[id]
+	if !ok || d.ownJob(userID, interview.JobApplicationID) == nil {
+		return nil
+	}
+	return interview
+}
+
+// CreateInterview adds an interview round to a job application
+func (s *Store) CreateInterview(ctx context.Context, interview *models.Interview) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, interview.JobApplicationID) == nil {
+		return database.ErrJobNotFound
+	}
+
+	stored := *interview
+	stored.ID = d.nextID("interviews")
+	stored.ScheduledAt = stored.ScheduledAt.UTC()
+	stored.CreatedAt = timestamp()
+	stored.UpdatedAt = stored.CreatedAt
+	stored.Job = nil
+	d.interviews[stored.ID] = &stored
+
+	interview.ID = stored.ID
+	return nil
+}
+
+// GetInterview retrieves an interview by ID
+func (s *Store) GetInterview(ctx context.Context, id int) (*models.Interview, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	interview := d.ownInterview(s.userID, id)
+	if interview == nil {
+		return nil, database.ErrInterviewNotFound
+	}
+	return copyInterview(interview), nil
+}
+
+// UpdateInterview updates an existing interview. The application it belongs to cannot change.
+func (s *Store) UpdateInterview(ctx context.Context, interview *models.Interview) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	stored := d.ownInterview(s.userID, interview.ID)
+	if stored == nil {
+		return database.ErrInterviewNotFound
+	}
+
+	stored.Round = interview.Round
+	stored.ScheduledAt = interview.ScheduledAt.UTC()
+	stored.DurationMinutes = interview.DurationMinutes
+	stored.Location = interview.Location
+	stored.Interviewers = interview.Interviewers
+	stored.Outcome = interview.Outcome
+	stored.Feedback = interview.Feedback
+	stored.UpdatedAt = timestamp()
+	return nil
+}
+
+// DeleteInterview deletes an interview by ID
+func (s *Store) DeleteInterview(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownInterview(s.userID, id) == nil {
+		return database.ErrInterviewNotFound
+	}
+
+	delete(d.interviews, id)
+	return nil
+}
+
+// GetInterviewsForJob retrieves a job application's interviews in the order they happen
+func (s *Store) GetInterviewsForJob(ctx context.Context, jobID int) ([]*models.Interview, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, jobID) == nil {
+		return nil, nil
+	}
+	return d.interviewsForJob(jobID), nil
+}
+
+func (d *data) interviewsForJob(jobID int) []*models.Interview {
+	var interviews []*models.Interview
+	for _, interview := range d.interviews {
+		if interview.JobApplicationID == jobID {
+			interviews = append(interviews, copyInterview(interview))
+		}
+	}
+	sortBySchedule(interviews)
+	return interviews
+}
+
+// GetUpcomingInterviews retrieves scheduled interviews that have not finished by now,
+// soonest first, each with its job application. A limit of zero returns them all.
+func (s *Store) GetUpcomingInterviews(ctx context.Context, now time.Time, limit int) ([]*models.Interview, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	// Like the SQL query, look back a day for interviews still in progress
+	since := now.Add(-24 * time.Hour)
+
+	var upcoming []*models.Interview
+	for _, interview := range d.interviewsWithJobs(s.userID) {
+		if interview.Outcome != models.OutcomeScheduled || interview.ScheduledAt.Before(since) || interview.EndsAt().Before(now) {
+			continue
+		}
+		upcoming = append(upcoming, interview)
+		if limit > 0 && len(upcoming) == limit {
+			break
+		}
+	}
+	return upcoming, nil
+}
+
+// GetAllInterviews retrieves every interview, oldest first, each with its job application
+func (s *Store) GetAllInterviews(ctx context.Context) ([]*models.Interview, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.interviewsWithJobs(s.userID), nil
+}
+
+// interviewsWithJobs lists the user's interviews in the order they happen, each with its job application
+func (d *data) interviewsWithJobs(userID int) []*models.Interview {
+	var interviews []*models.Interview
+	for _, stored := range d.interviews {
+		if record := d.ownJob(userID, stored.JobApplicationID); record != nil {
+			interview := copyInterview(stored)
+			interview.Job = copyJob(record.job)
+			interviews = append(interviews, interview)
+		}
+	}
+	sortBySchedule(interviews)
+	return interviews
+}
@@ -0,0 +1,531 @@
+// Package memstore keeps the data layer in memory, for handler tests that do not need SQLite
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// Store is an in-memory database.Store. It behaves like database.DB, returning the
+// same errors, but nothing is persisted and every Store from New starts empty.
+// Operations return their context's error if it is done before they start.
+type Store struct {
+	data *data
+	// userID owns every row this Store reads or writes
+	userID int
+}
+
+// Store implements database.Store
+var _ database.Store = (*Store)(nil)
+
+// data holds every table. Methods take the lock once; the unexported helpers
+// they call expect it to be held already.
+type data struct {
+	mu      sync.Mutex
+	lastIDs map[string]int
+
+	jobs           map[int]*jobRecord
+	statusEvents   []models.StatusEvent
+	companies      map[int]*companyRecord
+	aliases        map[int]*aliasRecord
+	tags           map[int]*tagRecord
+	jobTags        map[int]map[int]bool
+	contacts       map[int]*contactRecord
+	jobContacts    map[int]map[int]bool
+	interviews     map[int]*models.Interview
+	attachments    map[int]*attachmentRecord
+	statuses       map[int]*models.Status
+	transitions    map[int]map[int]bool
+	rules          map[int]*models.FollowUpRule
+	users          map[int]*models.User
+	sessions       map[string]session
+	calendarTokens map[int]string
+}
+
+type jobRecord struct {
+	userID int
+	job    models.JobApplication
+}
+
+// New returns an empty Store with the default workflow, follow-up rules and an admin
+// without a password, like a new SQLite database. It works with the admin's data.
+func New() *Store {
+	d := &data{
+		lastIDs:        make(map[string]int),
+		jobs:           make(map[int]*jobRecord),
+		companies:      make(map[int]*companyRecord),
+		aliases:        make(map[int]*aliasRecord),
+		tags:           make(map[int]*tagRecord),
+		jobTags:        make(map[int]map[int]bool),
+		contacts:       make(map[int]*contactRecord),
+		jobContacts:    make(map[int]map[int]bool),
+		interviews:     make(map[int]*models.Interview),
+		attachments:    make(map[int]*attachmentRecord),
+		statuses:       make(map[int]*models.Status),
+		transitions:    make(map[int]map[int]bool),
+		rules:          make(map[int]*models.FollowUpRule),
+		users:          make(map[int]*models.User),
+		sessions:       make(map[string]session),
+		calendarTokens: make(map[int]string),
+	}
+
+	now := timestamp()
+	admin := &models.User{ID: d.nextID("users"), Username: "admin", IsAdmin: true, CreatedAt: now, UpdatedAt: now}
+	d.users[admin.ID] = admin
+
+	for i, status := range models.GetDefaultStatuses() {
+		id := d.nextID("statuses")
+		d.statuses[id] = &models.Status{ID: id, Name: status.Name, Position: i + 1, Color: status.Color, Category: status.Category, Role: status.Role}
+	}
+	for _, rule := range []models.FollowUpRule{{Status: models.StatusApplied, FollowUpDays: 10}, {Status: models.StatusInReview, FollowUpDays: 7}} {
+		rule.ID, rule.CreatedAt, rule.UpdatedAt = d.nextID("rules"), now, now
+		d.rules[rule.ID] = &rule
+	}
+
+	return &Store{data: d, userID: admin.ID}
+}
+
+// ForUser returns a Store sharing this data that works with another user's
+func (s *Store) ForUser(userID int) database.Store {
+	return &Store{data: s.data, userID: userID}
+}
+
+// UserID returns the user whose data this Store works with
+func (s *Store) UserID() int {
+	return s.userID
+}
+
+// Close does nothing; the data lives until the Store is garbage collected
+func (s *Store) Close() error {
+	return nil
+}
+
+// lock takes the data lock and returns the function that releases it
+func (s *Store) lock() (*data, func()) {
+	s.data.mu.Lock()
+	return s.data, s.data.mu.Unlock
+}
+
+// nextID returns a new row ID for a table. Like SQLite, each table numbers its rows from 1.
+func (d *data) nextID(table string) int {
+	d.lastIDs[table]++
+	return d.lastIDs[table]
+}
+
+// timestamp returns the current time as SQLite's CURRENT_TIMESTAMP records it
+func timestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// copyJob returns a copy of a stored job application that callers may change freely
+func copyJob(job models.JobApplication) *models.JobApplication {
+	if job.NextActionDate != nil {
+		date := *job.NextActionDate
+		job.NextActionDate = &date
+	}
+	job.SalaryMin = copyInt(job.SalaryMin)
+	job.SalaryMax = copyInt(job.SalaryMax)
+	job.Tags, job.History, job.Contacts, job.Interviews, job.Attachments = nil, nil, nil, nil, nil
+	return &job
+}
+
+func copyInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	value := *n
+	return &value
+}
+
+// ownJob returns the user's job application, or nil
+func (d *data) ownJob(userID, id int) *jobRecord {
+	record, ok := d.jobs[id]
+	if !ok || record.userID != userID {
+		return nil
+	}
+	return record
+}
+
+// userJobs returns copies of the user's job applications that match, without tags, in no particular order
+func (d *data) userJobs(userID int, match func(*models.JobApplication) bool) []*models.JobApplication {
+	var jobs []*models.JobApplication
+	for _, record := range d.jobs {
+		if record.userID == userID && (match == nil || match(&record.job)) {
+			jobs = append(jobs, copyJob(record.job))
+		}
+	}
+	return jobs
+}
+
+// sortNewestFirst orders applications by date applied, then creation, newest first
+func sortNewestFirst(jobs []*models.JobApplication) {
+	sort.Slice(jobs, func(i, j int) bool {
+		a, b := jobs[i], jobs[j]
+		if !a.DateApplied.Equal(b.DateApplied) {
+			return a.DateApplied.After(b.DateApplied)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+}
+
+// CreateJobApplication creates a new job application with its tags and records its initial status.
+// The status must be one of the configured statuses, or database.ErrInvalidStatus is returned.
+func (s *Store) CreateJobApplication(ctx context.Context, job *models.JobApplication) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	status, err := d.resolveStatus(job.Status)
+	if err != nil {
+		return err
+	}
+	job.Status = status
+
+	record := &jobRecord{userID: s.userID, job: *copyJob(*job)}
+	record.job.ID = d.nextID("jobs")
+	record.job.CompanyID = d.resolveCompanyID(s.userID, job.Company)
+	record.job.CreatedAt = timestamp()
+	record.job.UpdatedAt = record.job.CreatedAt
+	d.jobs[record.job.ID] = record
+
+	d.recordStatusEvent(record.job.ID, "", job.Status)
+	d.setJobTags(s.userID, record.job.ID, job.Tags)
+
+	job.ID = record.job.ID
+	job.CompanyID = record.job.CompanyID
+	return nil
+}
+
+// GetJobApplication retrieves a job application by ID
+func (s *Store) GetJobApplication(ctx context.Context, id int) (*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.getJob(s.userID, id)
+}
+
+func (d *data) getJob(userID, id int) (*models.JobApplication, error) {
+	record := d.ownJob(userID, id)
+	if record == nil {
+		return nil, database.ErrJobNotFound
+	}
+
+	job := copyJob(record.job)
+	job.History = d.statusHistory(id)
+	job.Contacts = d.contactsForJob(userID, id)
+	job.Interviews = d.interviewsForJob(id)
+	job.Tags = d.tagsForJob(id)
+	job.Attachments = d.attachmentsForJob(id)
+	return job, nil
+}
+
+// GetAllJobApplications retrieves all job applications, ordered by date applied (newest first)
+func (s *Store) GetAllJobApplications(ctx context.Context) ([]*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.allJobs(s.userID), nil
+}
+
+func (d *data) allJobs(userID int) []*models.JobApplication {
+	jobs := d.userJobs(userID, nil)
+	sortNewestFirst(jobs)
+	d.attachTags(jobs)
+	return jobs
+}
+
+// UpdateJobApplication updates an existing job application and replaces its tags, recording
+// a status event when the status changes. A status change must be allowed by the current status's
+// transitions, or database.ErrTransitionNotAllowed is returned.
+func (s *Store) UpdateJobApplication(ctx context.Context, job *models.JobApplication) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	record := d.ownJob(s.userID, job.ID)
+	if record == nil {
+		return database.ErrJobNotFound
+	}
+	previousStatus := record.job.Status
+
+	status, err := d.resolveStatus(job.Status)
+	if err != nil {
+		return err
+	}
+	job.Status = status
+
+	if err := d.checkTransition(previousStatus, job.Status); err != nil {
+		return err
+	}
+
+	updated := copyJob(*job)
+	updated.CompanyID = d.resolveCompanyID(s.userID, job.Company)
+	updated.CreatedAt = record.job.CreatedAt
+	updated.UpdatedAt = timestamp()
+	record.job = *updated
+
+	if previousStatus != job.Status {
+		d.recordStatusEvent(job.ID, previousStatus, job.Status)
+	}
+	d.setJobTags(s.userID, job.ID, job.Tags)
+
+	job.CompanyID = updated.CompanyID
+	return nil
+}
+
+// DeleteJobApplication deletes a job application by ID, along with its attachments
+func (s *Store) DeleteJobApplication(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, id) == nil {
+		return database.ErrJobNotFound
+	}
+
+	d.deleteJob(id)
+	return nil
+}
+
+// deleteJob deletes a job application and everything that belongs to it
+func (d *data) deleteJob(id int) {
+	delete(d.jobs, id)
+	delete(d.jobTags, id)
+	delete(d.jobContacts, id)
+
+	events := d.statusEvents[:0]
+	for _, event := range d.statusEvents {
+		if event.JobApplicationID != id {
+			events = append(events, event)
+		}
+	}
+	d.statusEvents = events
+
+	for interviewID, interview := range d.interviews {
+		if interview.JobApplicationID == id {
+			delete(d.interviews, interviewID)
+		}
+	}
+	for attachmentID, attachment := range d.attachments {
+		if attachment.JobApplicationID == id {
+			delete(d.attachments, attachmentID)
+		}
+	}
+}
+
+// GetJobApplicationsByStatus retrieves job applications filtered by status
+func (s *Store) GetJobApplicationsByStatus(ctx context.Context, status string) ([]*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	jobs := d.userJobs(s.userID, func(job *models.JobApplication) bool { return job.Status == status })
+	sortNewestFirst(jobs)
+	d.attachTags(jobs)
+	return jobs, nil
+}
+
+// GetJobApplicationsWithFollowUp retrieves the job applications that have a follow-up date,
+// soonest first
+func (s *Store) GetJobApplicationsWithFollowUp(ctx context.Context) ([]*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	jobs := d.userJobs(s.userID, func(job *models.JobApplication) bool { return job.NextActionDate != nil })
+	sort.Slice(jobs, func(i, j int) bool {
+		a, b := jobs[i], jobs[j]
+		if !a.NextActionDate.Equal(*b.NextActionDate) {
+			return a.NextActionDate.Before(*b.NextActionDate)
+		}
+		return a.ID < b.ID
+	})
+	return jobs, nil
+}
+
+// GetStatusCounts returns counts of job applications by status
+func (s *Store) GetStatusCounts(ctx context.Context) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	counts := make(map[string]int)
+	for _, job := range d.userJobs(s.userID, nil) {
+		counts[job.Status]++
+	}
+	return counts, nil
+}
+
+// GetTotalJobApplicationCount returns the total count of all job applications
+func (s *Store) GetTotalJobApplicationCount(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return len(d.userJobs(s.userID, nil)), nil
+}
+
+// FindDuplicateJobApplication looks for an existing application that matches job,
+// either by job URL or by company, job title and date applied (ignoring case and
+// surrounding whitespace). It returns nil if there is no match.
+func (s *Store) FindDuplicateJobApplication(ctx context.Context, job *models.JobApplication) (*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	fold := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+	url := fold(job.JobURL)
+
+	jobs := d.userJobs(s.userID, func(existing *models.JobApplication) bool {
+		if url != "" && fold(existing.JobURL) == url {
+			return true
+		}
+		return fold(existing.Company) == fold(job.Company) && fold(existing.JobTitle) == fold(job.JobTitle) &&
+			existing.DateApplied.Equal(job.DateApplied)
+	})
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	first := jobs[0]
+	for _, candidate := range jobs {
+		if candidate.ID < first.ID {
+			first = candidate
+		}
+	}
+	return d.getJob(s.userID, first.ID)
+}
+
+// ListJobApplications returns one page of job applications along with the
+// total number of applications matching the filter
+func (s *Store) ListJobApplications(ctx context.Context, opts database.ListOptions) ([]*models.JobApplication, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	tags := models.NormalizeTags(opts.Tags)
+	location := strings.ToLower(opts.Location)
+
+	jobs := d.userJobs(s.userID, func(job *models.JobApplication) bool {
+		switch {
+		case opts.Status != "" && job.Status != opts.Status:
+			return false
+		case len(tags) > 0 && !d.hasTags(job.ID, tags, opts.MatchAllTags):
+			return false
+		case opts.WorkMode != "" && job.WorkMode != opts.WorkMode:
+			return false
+		case location != "" && !strings.Contains(strings.ToLower(job.Location), location):
+			return false
+		case opts.MinSalary != nil && (topOfRange(job) == nil || *topOfRange(job) < *opts.MinSalary):
+			return false
+		}
+		return true
+	})
+	total := len(jobs)
+
+	sortJobs(jobs, opts.Sort, opts.Descending)
+
+	if opts.Limit > 0 {
+		start := min(opts.Offset, len(jobs))
+		jobs = jobs[start:min(start+opts.Limit, len(jobs))]
+	}
+
+	d.attachTags(jobs)
+	return jobs, total, nil
+}
+
+// topOfRange is the salary applications sort and filter by: the top of the range,
+// or the bottom if that is all we know
+func topOfRange(job *models.JobApplication) *int {
+	if job.SalaryMax != nil {
+		return job.SalaryMax
+	}
+	return job.SalaryMin
+}
+
+// sortJobs orders applications by one of the database.Sort* keys like ListJobApplications
+// does in SQL: unknown values last, then by creation and ID in the same direction
+func sortJobs(jobs []*models.JobApplication, key string, descending bool) {
+	// compare returns a negative number if a sorts first in ascending order
+	compare := func(a, b *models.JobApplication) int {
+		switch key {
+		case database.SortCompany:
+			return strings.Compare(strings.ToLower(a.Company), strings.ToLower(b.Company))
+		case database.SortStatus:
+			return strings.Compare(a.Status, b.Status)
+		case database.SortUpdated:
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		case database.SortSalary:
+			return *topOfRange(a) - *topOfRange(b)
+		default:
+			return a.DateApplied.Compare(b.DateApplied)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		a, b := jobs[i], jobs[j]
+		if key == database.SortSalary {
+			if aKnown, bKnown := topOfRange(a) != nil, topOfRange(b) != nil; aKnown != bKnown {
+				return aKnown
+			} else if !aKnown {
+				return tieBreak(a, b, descending)
+			}
+		}
+		if c := compare(a, b); c != 0 {
+			return (c < 0) != descending
+		}
+		return tieBreak(a, b, descending)
+	})
+}
+
+// tieBreak orders applications with equal sort keys by creation, then ID
+func tieBreak(a, b *models.JobApplication, descending bool) bool {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return (c < 0) != descending
+	}
+	return (a.ID < b.ID) != descending
+}
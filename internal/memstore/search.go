@@ -0,0 +1,55 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// maxSearchResults caps the number of results a search returns, as in the database package
+const maxSearchResults = 100
+
+// SearchJobApplications searches job title, company, notes and job URL, optionally
+// limited to a status. It matches and highlights words as the database's search
+// does, and ranks matches in the title and company above matches in notes and URLs.
+func (s *Store) SearchJobApplications(ctx context.Context, text, status string) ([]models.SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := database.ParseSearchQuery(text)
+	if query.Empty() {
+		return nil, nil
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	jobs := d.userJobs(s.userID, nil)
+	sortNewestFirst(jobs)
+
+	var results []models.SearchResult
+	for _, job := range jobs {
+		if status != "" && job.Status != status {
+			continue
+		}
+
+		if result, ok := query.Match(job); ok {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		return a.Job.DateApplied.After(b.Job.DateApplied)
+	})
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	return results, nil
+}
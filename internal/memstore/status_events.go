@@ -0,0 +1,80 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"hunter-seeker/internal/models"
+)
+
+// recordStatusEvent stores a status change
+func (d *data) recordStatusEvent(jobID int, fromStatus, toStatus string) {
+	d.statusEvents = append(d.statusEvents, models.StatusEvent{
+		ID:               d.nextID("statusEvents"),
+		JobApplicationID: jobID,
+		FromStatus:       fromStatus,
+		ToStatus:         toStatus,
+		ChangedAt:        timestamp(),
+	})
+}
+
+// statusHistory returns a job application's status changes, oldest first
+func (d *data) statusHistory(jobID int) []models.StatusEvent {
+	var events []models.StatusEvent
+	for _, event := range d.statusEvents {
+		if event.JobApplicationID == jobID {
+			events = append(events, event)
+		}
+	}
+	// Events are appended as they happen, so they are already in order
+	return events
+}
+
+// GetStatusHistory retrieves the status changes of a job application, oldest first
+func (s *Store) GetStatusHistory(ctx context.Context, jobID int) ([]models.StatusEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, jobID) == nil {
+		return nil, nil
+	}
+	return d.statusHistory(jobID), nil
+}
+
+// GetJobApplicationsWithHistory retrieves job applications applied for between from and to
+// (inclusive calendar dates, zero means unbounded), each with its status history loaded
+func (s *Store) GetJobApplicationsWithHistory(ctx context.Context, from, to time.Time) ([]*models.JobApplication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	jobs := d.userJobs(s.userID, func(job *models.JobApplication) bool {
+		if !from.IsZero() && job.DateApplied.Before(from) {
+			return false
+		}
+		if !to.IsZero() && !job.DateApplied.Before(to.AddDate(0, 0, 1)) {
+			return false
+		}
+		return true
+	})
+	sort.Slice(jobs, func(i, j int) bool {
+		a, b := jobs[i], jobs[j]
+		if !a.DateApplied.Equal(b.DateApplied) {
+			return a.DateApplied.Before(b.DateApplied)
+		}
+		return a.ID < b.ID
+	})
+
+	for _, job := range jobs {
+		job.History = d.statusHistory(job.ID)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,371 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// orderedStatuses returns the stored statuses in workflow order
+func (d *data) orderedStatuses() []*models.Status {
+	statuses := make([]*models.Status, 0, len(d.statuses))
+	for _, status := range d.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Position != statuses[j].Position {
+			return statuses[i].Position < statuses[j].Position
+		}
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses
+}
+
+// findStatus returns the status with a name, ignoring case, or nil
+func (d *data) findStatus(name string) *models.Status {
+	for _, status := range d.statuses {
+		if strings.EqualFold(status.Name, name) {
+			return status
+		}
+	}
+	return nil
+}
+
+// noResponseStatus returns the name of the status with the no-response role,
+// or "" if no status has it
+func (d *data) noResponseStatus() string {
+	for _, status := range d.statuses {
+		if status.Role == models.RoleNoResponse {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// takeRole clears a role only one status may have from every status but id
+func (d *data) takeRole(role string, id int) {
+	if role != models.RoleNoResponse {
+		return
+	}
+	for _, status := range d.statuses {
+		if status.Role == role && status.ID != id {
+			status.Role = ""
+		}
+	}
+}
+
+// GetStatuses retrieves the configured statuses in workflow order,
+// with their allowed transitions and how many of the user's applications use them
+func (s *Store) GetStatuses(ctx context.Context) ([]*models.Status, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.getStatuses(s.userID), nil
+}
+
+func (d *data) getStatuses(userID int) []*models.Status {
+	ordered := d.orderedStatuses()
+
+	var statuses []*models.Status
+	for _, stored := range ordered {
+		status := *stored
+		status.Transitions = nil
+		for _, to := range ordered {
+			if d.transitions[status.ID][to.ID] {
+				status.Transitions = append(status.Transitions, to.Name)
+			}
+		}
+		for _, job := range d.jobs {
+			if job.userID == userID && job.job.Status == status.Name {
+				status.ApplicationCount++
+			}
+		}
+		statuses = append(statuses, &status)
+	}
+	return statuses
+}
+
+// GetStatus retrieves a single status by ID
+func (s *Store) GetStatus(ctx context.Context, id int) (*models.Status, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	for _, status := range d.getStatuses(s.userID) {
+		if status.ID == id {
+			return status, nil
+		}
+	}
+	return nil, database.ErrStatusNotFound
+}
+
+// GetStatusNames returns the names of the configured statuses in workflow order
+func (s *Store) GetStatusNames(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	var names []string
+	for _, status := range d.orderedStatuses() {
+		names = append(names, status.Name)
+	}
+	return names, nil
+}
+
+// CreateStatus adds a status to the end of the workflow. Giving it the no-response
+// role takes the role from the status that had it.
+func (s *Store) CreateStatus(ctx context.Context, status *models.Status) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkStatusName(status.Name, 0); err != nil {
+		return err
+	}
+
+	position := 1
+	for _, existing := range d.statuses {
+		position = max(position, existing.Position+1)
+	}
+
+	d.takeRole(status.Role, 0)
+	status.ID = d.nextID("statuses")
+	status.Position = position
+	d.statuses[status.ID] = &models.Status{ID: status.ID, Name: status.Name, Position: position, Color: status.Color, Category: status.Category, Role: status.Role}
+	return nil
+}
+
+// UpdateStatus saves a status's name, color, category and role. Renaming a status
+// renames it everywhere it is used, including the history of applications, and
+// giving it the no-response role takes the role from the status that had it.
+func (s *Store) UpdateStatus(ctx context.Context, status *models.Status) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkStatusName(status.Name, status.ID); err != nil {
+		return err
+	}
+
+	stored, ok := d.statuses[status.ID]
+	if !ok {
+		return database.ErrStatusNotFound
+	}
+	oldName := stored.Name
+
+	stored.Name = status.Name
+	stored.Color = status.Color
+	stored.Category = status.Category
+	d.takeRole(status.Role, status.ID)
+	stored.Role = status.Role
+
+	if oldName != status.Name {
+		for _, job := range d.jobs {
+			if job.job.Status == oldName {
+				job.job.Status = status.Name
+				job.job.UpdatedAt = timestamp()
+			}
+		}
+		for i := range d.statusEvents {
+			event := &d.statusEvents[i]
+			if event.FromStatus == oldName {
+				event.FromStatus = status.Name
+			}
+			if event.ToStatus == oldName {
+				event.ToStatus = status.Name
+			}
+		}
+		for _, rule := range d.rules {
+			if rule.Status == oldName {
+				rule.Status = status.Name
+				rule.UpdatedAt = timestamp()
+			}
+		}
+	}
+
+	return nil
+}
+
+// MoveStatus swaps a status with its neighbour earlier (negative offset) or later
+// (positive offset) in the workflow. Moving past either end does nothing.
+func (s *Store) MoveStatus(ctx context.Context, id int, offset int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	statuses := d.orderedStatuses()
+	index := -1
+	for i, status := range statuses {
+		if status.ID == id {
+			index = i
+		}
+	}
+	if index == -1 {
+		return database.ErrStatusNotFound
+	}
+
+	target := index + offset
+	if offset == 0 || target < 0 || target >= len(statuses) {
+		return nil
+	}
+
+	// Renumber the whole list so positions stay unique and contiguous
+	statuses[index], statuses[target] = statuses[target], statuses[index]
+	for i, status := range statuses {
+		status.Position = i + 1
+	}
+	return nil
+}
+
+// SetStatusTransitions replaces the statuses an application may move to from a status.
+// An empty list allows any transition.
+func (s *Store) SetStatusTransitions(ctx context.Context, id int, toIDs []int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if _, ok := d.statuses[id]; !ok {
+		return database.ErrStatusNotFound
+	}
+
+	delete(d.transitions, id)
+	for _, toID := range toIDs {
+		if _, ok := d.statuses[toID]; !ok || toID == id {
+			continue
+		}
+		if d.transitions[id] == nil {
+			d.transitions[id] = make(map[int]bool)
+		}
+		d.transitions[id][toID] = true
+	}
+	return nil
+}
+
+// DeleteStatus deletes a status that no application, of any user, is using,
+// along with its transitions and follow-up rule
+func (s *Store) DeleteStatus(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	status, ok := d.statuses[id]
+	if !ok {
+		return database.ErrStatusNotFound
+	}
+
+	for _, job := range d.jobs {
+		if job.job.Status == status.Name {
+			return database.ErrStatusInUse
+		}
+	}
+
+	for ruleID, rule := range d.rules {
+		if rule.Status == status.Name {
+			delete(d.rules, ruleID)
+		}
+	}
+	delete(d.statuses, id)
+	delete(d.transitions, id)
+	for _, toIDs := range d.transitions {
+		delete(toIDs, id)
+	}
+	return nil
+}
+
+// ResolveStatus returns the configured spelling of a status name, matched
+// case-insensitively, or database.ErrInvalidStatus if there is no such status
+func (s *Store) ResolveStatus(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	return d.resolveStatus(name)
+}
+
+func (d *data) resolveStatus(name string) (string, error) {
+	status := d.findStatus(name)
+	if status == nil {
+		return "", fmt.Errorf("%w %q", database.ErrInvalidStatus, name)
+	}
+	return status.Name, nil
+}
+
+// DefaultStatus returns the first status of the workflow, used when none is given
+func (s *Store) DefaultStatus(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	statuses := d.orderedStatuses()
+	if len(statuses) == 0 {
+		return "", database.ErrInvalidStatus
+	}
+	return statuses[0].Name, nil
+}
+
+// checkTransition verifies that an application may move from one status to another
+func (d *data) checkTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	status := d.findStatus(from)
+	if status == nil {
+		// Applications in a status that no longer exists may move anywhere
+		return nil
+	}
+
+	allowed := len(d.transitions[status.ID]) == 0
+	for toID := range d.transitions[status.ID] {
+		if strings.EqualFold(d.statuses[toID].Name, to) {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w from %q to %q", database.ErrTransitionNotAllowed, from, to)
+	}
+	return nil
+}
+
+// checkStatusName verifies that no other status has the given name
+func (d *data) checkStatusName(name string, id int) error {
+	if status := d.findStatus(name); status != nil && status.ID != id {
+		return database.ErrStatusNameTaken
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"hunter-seeker/internal/models"
+)
+
+type tagRecord struct {
+	id     int
+	userID int
+	name   string
+}
+
+// GetTags retrieves every tag in use, alphabetically, with the number of applications using it
+func (s *Store) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	counts := make(map[int]int)
+	for _, tagIDs := range d.jobTags {
+		for tagID := range tagIDs {
+			counts[tagID]++
+		}
+	}
+
+	var tags []*models.Tag
+	for _, tag := range d.tags {
+		if tag.userID == s.userID && counts[tag.id] > 0 {
+			tags = append(tags, &models.Tag{ID: tag.id, Name: tag.name, ApplicationCount: counts[tag.id]})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name) })
+	return tags, nil
+}
+
+// GetTagsForJob retrieves the names of a job application's tags, alphabetically
+func (s *Store) GetTagsForJob(ctx context.Context, jobID int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if d.ownJob(s.userID, jobID) == nil {
+		return []string{}, nil
+	}
+	return d.tagsForJob(jobID), nil
+}
+
+func (d *data) tagsForJob(jobID int) []string {
+	names := []string{}
+	for tagID := range d.jobTags[jobID] {
+		names = append(names, d.tags[tagID].name)
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	return names
+}
+
+// attachTags loads the tags of a list of job applications
+func (d *data) attachTags(jobs []*models.JobApplication) {
+	for _, job := range jobs {
+		job.Tags = d.tagsForJob(job.ID)
+	}
+}
+
+// findTag returns the user's tag with a name, ignoring case, or nil
+func (d *data) findTag(userID int, name string) *tagRecord {
+	for _, tag := range d.tags {
+		if tag.userID == userID && strings.EqualFold(tag.name, name) {
+			return tag
+		}
+	}
+	return nil
+}
+
+// setJobTags replaces a job application's tags, creating the user's tags that do not exist yet.
+// Tags are matched case-insensitively, and tags no application uses any more are removed.
+func (d *data) setJobTags(userID, jobID int, tags []string) {
+	delete(d.jobTags, jobID)
+
+	for _, name := range models.NormalizeTags(tags) {
+		tag := d.findTag(userID, name)
+		if tag == nil {
+			tag = &tagRecord{id: d.nextID("tags"), userID: userID, name: name}
+			d.tags[tag.id] = tag
+		}
+		if d.jobTags[jobID] == nil {
+			d.jobTags[jobID] = make(map[int]bool)
+		}
+		d.jobTags[jobID][tag.id] = true
+	}
+
+	used := make(map[int]bool)
+	for _, tagIDs := range d.jobTags {
+		for tagID := range tagIDs {
+			used[tagID] = true
+		}
+	}
+	for id := range d.tags {
+		if !used[id] {
+			delete(d.tags, id)
+		}
+	}
+}
+
+// hasTags reports whether a job application has all (or, unless matchAll, any) of
+// the given normalized tags
+func (d *data) hasTags(jobID int, tags []string, matchAll bool) bool {
+	matched := 0
+	for tagID := range d.jobTags[jobID] {
+		for _, name := range tags {
+			if strings.EqualFold(d.tags[tagID].name, name) {
+				matched++
+			}
+		}
+	}
+	if matchAll {
+		return matched == len(tags)
+	}
+	return matched > 0
+}
@@ -0,0 +1,383 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"hunter-seeker/internal/auth"
+	"hunter-seeker/internal/database"
+	"hunter-seeker/internal/models"
+)
+
+// session is a login session, stored by the hash of its token
+type session struct {
+	userID    int
+	expiresAt time.Time
+}
+
+// orderedUsers returns the stored users by username
+func (d *data) orderedUsers() []*models.User {
+	users := make([]*models.User, 0, len(d.users))
+	for _, user := range d.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		a, b := strings.ToLower(users[i].Username), strings.ToLower(users[j].Username)
+		if a != b {
+			return a < b
+		}
+		return users[i].ID < users[j].ID
+	})
+	return users
+}
+
+// copyUser returns a copy of a stored user
+func copyUser(stored *models.User) *models.User {
+	user := *stored
+	user.ApplicationCount = 0
+	return &user
+}
+
+// GetUsers retrieves every user by username, with the number of applications each has
+func (s *Store) GetUsers(ctx context.Context) ([]*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	var users []*models.User
+	for _, stored := range d.orderedUsers() {
+		user := copyUser(stored)
+		user.ApplicationCount = len(d.userJobs(user.ID, nil))
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *Store) GetUser(ctx context.Context, id int) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	user, ok := d.users[id]
+	if !ok {
+		return nil, database.ErrUserNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetUserByUsername retrieves a user by username, ignoring case
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	for _, user := range d.users {
+		if strings.EqualFold(user.Username, username) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, database.ErrUserNotFound
+}
+
+// DefaultUser returns the first admin, whose data is shown when no login is required
+func (s *Store) DefaultUser(ctx context.Context) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	var first *models.User
+	for _, user := range d.users {
+		if user.IsAdmin && (first == nil || user.ID < first.ID) {
+			first = user
+		}
+	}
+	if first == nil {
+		return nil, database.ErrUserNotFound
+	}
+	return copyUser(first), nil
+}
+
+// AdminHasPassword reports whether any admin can log in with a password
+func (s *Store) AdminHasPassword(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	for _, user := range d.users {
+		if user.IsAdmin && user.HasPassword() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateUser adds a user with the given username, password hash and admin flag
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkUsername(user.Username, 0); err != nil {
+		return err
+	}
+
+	now := timestamp()
+	user.ID, user.CreatedAt, user.UpdatedAt = d.nextID("users"), now, now
+	d.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// UpdateUser saves a user's username and admin flag. The last admin cannot stop
+// being one, or database.ErrLastAdmin is returned.
+func (s *Store) UpdateUser(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if err := d.checkUsername(user.Username, user.ID); err != nil {
+		return err
+	}
+
+	stored, ok := d.users[user.ID]
+	if !ok {
+		return database.ErrUserNotFound
+	}
+	if stored.IsAdmin && !user.IsAdmin && !d.otherAdminExists(user.ID) {
+		return database.ErrLastAdmin
+	}
+
+	stored.Username = user.Username
+	stored.IsAdmin = user.IsAdmin
+	stored.UpdatedAt = timestamp()
+	return nil
+}
+
+// SetUserPassword saves the hash of a user's password, or "" to stop them logging in,
+// and ends their sessions
+func (s *Store) SetUserPassword(ctx context.Context, id int, hash string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	stored, ok := d.users[id]
+	if !ok {
+		return database.ErrUserNotFound
+	}
+
+	stored.PasswordHash = hash
+	stored.UpdatedAt = timestamp()
+	for tokenHash, session := range d.sessions {
+		if session.userID == id {
+			delete(d.sessions, tokenHash)
+		}
+	}
+	return nil
+}
+
+// DeleteUser deletes a user along with all their data and attachments.
+// The last admin cannot be deleted, or database.ErrLastAdmin is returned.
+func (s *Store) DeleteUser(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	stored, ok := d.users[id]
+	if !ok {
+		return database.ErrUserNotFound
+	}
+	if stored.IsAdmin && !d.otherAdminExists(id) {
+		return database.ErrLastAdmin
+	}
+
+	for jobID, record := range d.jobs {
+		if record.userID == id {
+			d.deleteJob(jobID)
+		}
+	}
+	for contactID, record := range d.contacts {
+		if record.userID == id {
+			d.deleteContact(contactID)
+		}
+	}
+	for companyID, record := range d.companies {
+		if record.userID == id {
+			delete(d.companies, companyID)
+		}
+	}
+	for aliasID, record := range d.aliases {
+		if record.userID == id {
+			delete(d.aliases, aliasID)
+		}
+	}
+	for tagID, record := range d.tags {
+		if record.userID == id {
+			delete(d.tags, tagID)
+		}
+	}
+	for tokenHash, session := range d.sessions {
+		if session.userID == id {
+			delete(d.sessions, tokenHash)
+		}
+	}
+	delete(d.calendarTokens, id)
+	delete(d.users, id)
+	return nil
+}
+
+// otherAdminExists reports whether an admin other than the given user exists
+func (d *data) otherAdminExists(id int) bool {
+	for _, user := range d.users {
+		if user.IsAdmin && user.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *data) checkUsername(username string, id int) error {
+	for _, user := range d.users {
+		if strings.EqualFold(user.Username, username) && user.ID != id {
+			return database.ErrUsernameTaken
+		}
+	}
+	return nil
+}
+
+// CreateSession records a user's login session by the hash of its token
+func (s *Store) CreateSession(ctx context.Context, tokenHash string, userID int, expiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	d.sessions[tokenHash] = session{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+// SessionUser returns the user logged in with a session, or nil if the session
+// does not exist or expired before now
+func (s *Store) SessionUser(ctx context.Context, tokenHash string, now time.Time) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	session, ok := d.sessions[tokenHash]
+	if !ok || !session.expiresAt.After(now) {
+		return nil, nil
+	}
+	user, ok := d.users[session.userID]
+	if !ok {
+		return nil, nil
+	}
+	return copyUser(user), nil
+}
+
+// DeleteSession ends a login session. Ending a session that does not exist is not an error.
+func (s *Store) DeleteSession(ctx context.Context, tokenHash string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	delete(d.sessions, tokenHash)
+	return nil
+}
+
+// DeleteExpiredSessions removes sessions that expired before now
+func (s *Store) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	for tokenHash, session := range d.sessions {
+		if !session.expiresAt.After(now) {
+			delete(d.sessions, tokenHash)
+		}
+	}
+	return nil
+}
+
+// CalendarToken returns the secret token that opens the user's calendar feed without
+// a login, creating it the first time
+func (s *Store) CalendarToken(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if _, ok := d.users[s.userID]; !ok {
+		return "", database.ErrUserNotFound
+	}
+	if token, ok := d.calendarTokens[s.userID]; ok {
+		return token, nil
+	}
+
+	token, err := auth.NewSessionToken()
+	if err != nil {
+		return "", err
+	}
+	d.calendarTokens[s.userID] = token
+	return token, nil
+}
+
+// CalendarTokenUser returns the user whose calendar feed a token opens, or nil if none does
+func (s *Store) CalendarTokenUser(ctx context.Context, token string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d, unlock := s.lock()
+	defer unlock()
+
+	if token == "" {
+		return nil, nil
+	}
+	for userID, userToken := range d.calendarTokens {
+		if userToken == token {
+			return copyUser(d.users[userID]), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Attachment kinds
+const (
+	AttachmentResume      = "Resume"
+	AttachmentCoverLetter = "Cover letter"
+	AttachmentOffer       = "Offer letter"
+	AttachmentTakeHome    = "Take-home"
+	AttachmentOther       = "Other"
+)
+
+// GetAttachmentKinds returns the kinds of document an attachment can be
+func GetAttachmentKinds() []string {
+	return []string{AttachmentResume, AttachmentCoverLetter, AttachmentOffer, AttachmentTakeHome, AttachmentOther}
+}
+
+// IsValidAttachmentKind reports whether kind is one of GetAttachmentKinds
+func IsValidAttachmentKind(kind string) bool {
+	for _, k := range GetAttachmentKinds() {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Attachment is a file, such as the resume version sent or an offer letter,
+// uploaded against a job application
+type Attachment struct {
+	ID               int    `json:"id" db:"id"`
+	JobApplicationID int    `json:"job_application_id" db:"job_application_id"`
+	Kind             string `json:"kind" db:"kind"`
+	// Filename is the name the file was uploaded with
+	Filename    string `json:"filename" db:"filename"`
+	ContentType string `json:"content_type" db:"content_type"`
+	Size        int64  `json:"size" db:"size"`
+	// StoredName is the name of the file in the attachments directory
+	StoredName string    `json:"-" db:"stored_name"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// SizeLabel formats the file size for display, such as "240 KB"
+func (a *Attachment) SizeLabel() string {
+	switch {
+	case a.Size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(a.Size)/(1<<20))
+	case a.Size >= 1<<10:
+		return fmt.Sprintf("%d KB", a.Size>>10)
+	}
+	return fmt.Sprintf("%d bytes", a.Size)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Company groups the job applications made to one employer, whatever
+// spelling of its name each application used
+type Company struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Size      string    `json:"size" db:"size"`
+	Industry  string    `json:"industry" db:"industry"`
+	Website   string    `json:"website" db:"website"`
+	Notes     string    `json:"notes" db:"notes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	ApplicationCount int `json:"application_count" db:"-"`
+	RejectionCount   int `json:"rejection_count" db:"-"`
+
+	// Aliases and Applications are only populated when a single company is loaded
+	Aliases      []CompanyAlias    `json:"aliases,omitempty" db:"-"`
+	Applications []*JobApplication `json:"applications,omitempty" db:"-"`
+}
+
+// CompanyAlias is another name that refers to a company, such as a former name or parent brand
+type CompanyAlias struct {
+	ID        int    `json:"id" db:"id"`
+	CompanyID int    `json:"company_id" db:"company_id"`
+	Alias     string `json:"alias" db:"alias"`
+}
+
+// companySuffixes are legal-form words dropped from the end of company names
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "lp": true,
+	"ltd": true, "limited": true, "corp": true, "corporation": true, "co": true,
+	"company": true, "plc": true, "gmbh": true, "ag": true, "sa": true,
+	"bv": true, "nv": true, "pty": true, "srl": true, "ab": true, "oy": true,
+}
+
+// NormalizeCompanyName reduces a company name to the key used to match
+// spellings of the same company: lower case, punctuation removed and
+// trailing legal forms dropped, so "Google", "google" and "Google LLC" match.
+func NormalizeCompanyName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	return strings.Join(words, " ")
+}
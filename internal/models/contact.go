@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Contact is a person met during the job search, such as a recruiter or interviewer.
+// A contact can be linked to any number of job applications.
+type Contact struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Role        string    `json:"role" db:"role"`
+	Email       string    `json:"email" db:"email"`
+	Phone       string    `json:"phone" db:"phone"`
+	LinkedInURL string    `json:"linkedin_url" db:"linkedin_url"`
+	Notes       string    `json:"notes" db:"notes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Applications is only populated when a single contact is loaded
+	Applications []*JobApplication `json:"applications,omitempty" db:"-"`
+}
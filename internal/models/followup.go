@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// FollowUpRule flags applications that have sat in a status without an update.
+// After FollowUpDays they need attention; after NoResponseDays (if non-zero)
+// they are moved to StatusNoResponse automatically.
+type FollowUpRule struct {
+	ID             int       `json:"id" db:"id"`
+	Status         string    `json:"status" db:"status"`
+	FollowUpDays   int       `json:"follow_up_days" db:"follow_up_days"`
+	NoResponseDays int       `json:"no_response_days" db:"no_response_days"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
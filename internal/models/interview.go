@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Interview outcomes
+const (
+	OutcomeScheduled = "Scheduled"
+	OutcomePassed    = "Passed"
+	OutcomeFailed    = "Failed"
+	OutcomeCancelled = "Cancelled"
+)
+
+// GetInterviewOutcomes returns the outcomes an interview can have
+func GetInterviewOutcomes() []string {
+	return []string{OutcomeScheduled, OutcomePassed, OutcomeFailed, OutcomeCancelled}
+}
+
+// IsValidInterviewOutcome reports whether outcome is one of GetInterviewOutcomes
+func IsValidInterviewOutcome(outcome string) bool {
+	for _, o := range GetInterviewOutcomes() {
+		if o == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// Interview is a scheduled interview round for a job application
+type Interview struct {
+	ID               int       `json:"id" db:"id"`
+	JobApplicationID int       `json:"job_application_id" db:"job_application_id"`
+	Round            string    `json:"round" db:"round"`
+	ScheduledAt      time.Time `json:"scheduled_at" db:"scheduled_at"`
+	DurationMinutes  int       `json:"duration_minutes" db:"duration_minutes"`
+	// Location is an address or a video call link
+	Location     string    `json:"location" db:"location"`
+	Interviewers string    `json:"interviewers" db:"interviewers"`
+	Outcome      string    `json:"outcome" db:"outcome"`
+	Feedback     string    `json:"feedback" db:"feedback"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	// Job is only populated when interviews are listed across applications
+	Job *JobApplication `json:"job,omitempty" db:"-"`
+}
+
+// EndsAt returns when the interview is scheduled to finish
+func (i *Interview) EndsAt() time.Time {
+	return i.ScheduledAt.Add(time.Duration(i.DurationMinutes) * time.Minute)
+}
+
+// LocationURL returns the location if it is a link, such as a video call URL
+func (i *Interview) LocationURL() string {
+	if strings.HasPrefix(i.Location, "https://") || strings.HasPrefix(i.Location, "http://") {
+		return i.Location
+	}
+	return ""
+}
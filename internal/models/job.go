@@ -1,6 +1,12 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // JobApplication represents a job application entry
 type JobApplication struct {
@@ -11,11 +17,58 @@ type JobApplication struct {
 	Status      string    `json:"status" db:"status"`
 	JobURL      string    `json:"job_url" db:"job_url"`
 	Notes       string    `json:"notes" db:"notes"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	CompanyID   int       `json:"company_id,omitempty" db:"company_id"`
+	// NextActionDate is the optional follow-up date, shown as "Follow-up date"
+	NextActionDate *time.Time `json:"next_action_date" db:"next_action_date"`
+	// SalaryMin and SalaryMax are the yearly salary range in SalaryCurrency; either may be unknown
+	SalaryMin      *int   `json:"salary_min" db:"salary_min"`
+	SalaryMax      *int   `json:"salary_max" db:"salary_max"`
+	SalaryCurrency string `json:"salary_currency" db:"salary_currency"`
+	// Equity is free text such as "0.1% over 4 years"
+	Equity   string `json:"equity" db:"equity"`
+	Location string `json:"location" db:"location"`
+	// WorkMode is one of GetWorkModes, or empty if unknown
+	WorkMode  string    `json:"work_mode" db:"work_mode"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// Tags are the application's labels in alphabetical order
+	Tags []string `json:"tags" db:"-"`
+
+	// History, Contacts, Interviews and Attachments are only populated when a single application is loaded
+	History     []StatusEvent `json:"history,omitempty" db:"-"`
+	Contacts    []*Contact    `json:"contacts,omitempty" db:"-"`
+	Interviews  []*Interview  `json:"interviews,omitempty" db:"-"`
+	Attachments []*Attachment `json:"attachments,omitempty" db:"-"`
 }
 
-// JobStatus constants for common statuses
+// StatusEvent records a single change of a job application's status.
+// The first event of every application has an empty FromStatus.
+type StatusEvent struct {
+	ID               int       `json:"id" db:"id"`
+	JobApplicationID int       `json:"job_application_id" db:"job_application_id"`
+	FromStatus       string    `json:"from_status" db:"from_status"`
+	ToStatus         string    `json:"to_status" db:"to_status"`
+	ChangedAt        time.Time `json:"changed_at" db:"changed_at"`
+}
+
+// SearchResult is a job application matched by a full-text search.
+// Highlighted fields wrap matched terms in HighlightStart and HighlightEnd.
+type SearchResult struct {
+	Job              *JobApplication `json:"job"`
+	TitleHighlight   string          `json:"title_highlight"`
+	CompanyHighlight string          `json:"company_highlight"`
+	NotesSnippet     string          `json:"notes_snippet"`
+	Rank             float64         `json:"rank"`
+}
+
+// Markers used around matched terms in SearchResult highlights
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// Status names of the default workflow. Statuses are stored in the database and
+// can be renamed, so these are only used for defaults and built-in rules.
 const (
 	StatusApplied     = "Applied"
 	StatusInReview    = "In Review"
@@ -28,17 +81,112 @@ const (
 	StatusNoResponse  = "No Response"
 )
 
-// GetCommonStatuses returns a list of common job application statuses
+// GetCommonStatuses returns the names of the default workflow's statuses
 func GetCommonStatuses() []string {
-	return []string{
-		StatusApplied,
-		StatusInReview,
-		StatusPhoneScreen,
-		StatusInterview,
-		StatusTechnical,
-		StatusOffer,
-		StatusRejected,
-		StatusWithdrawn,
-		StatusNoResponse,
+	defaults := GetDefaultStatuses()
+	names := make([]string, len(defaults))
+	for i, status := range defaults {
+		names[i] = status.Name
+	}
+	return names
+}
+
+// Work modes of a job
+const (
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+	WorkModeOnsite = "onsite"
+)
+
+// GetWorkModes returns the work modes a job can have
+func GetWorkModes() []string {
+	return []string{WorkModeRemote, WorkModeHybrid, WorkModeOnsite}
+}
+
+// WorkModeLabel returns the display name of a work mode
+func WorkModeLabel(mode string) string {
+	switch mode {
+	case WorkModeRemote:
+		return "Remote"
+	case WorkModeHybrid:
+		return "Hybrid"
+	case WorkModeOnsite:
+		return "On-site"
+	}
+	return mode
+}
+
+// ParseWorkMode reads a work mode, accepting common spellings such as "On-site",
+// "In office" or "WFH". An empty value is valid and means unknown.
+func ParseWorkMode(s string) (string, bool) {
+	normalized := strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "")
+
+	switch normalized {
+	case "":
+		return "", true
+	case "remote", "wfh", "fullyremote", "remoteonly":
+		return WorkModeRemote, true
+	case "hybrid":
+		return WorkModeHybrid, true
+	case "onsite", "inoffice", "office", "inperson":
+		return WorkModeOnsite, true
+	}
+	return "", false
+}
+
+// SalaryRange formats the salary range for display, such as "USD 120,000–150,000",
+// "from 100,000" or "up to 90,000". It returns "" if no salary is known.
+func (j *JobApplication) SalaryRange() string {
+	var salary string
+	switch {
+	case j.SalaryMin != nil && j.SalaryMax != nil && *j.SalaryMin != *j.SalaryMax:
+		salary = formatAmount(*j.SalaryMin) + "–" + formatAmount(*j.SalaryMax)
+	case j.SalaryMin != nil:
+		salary = formatAmount(*j.SalaryMin)
+		if j.SalaryMax == nil {
+			salary = "from " + salary
+		}
+	case j.SalaryMax != nil:
+		salary = "up to " + formatAmount(*j.SalaryMax)
+	default:
+		return ""
+	}
+
+	if j.SalaryCurrency != "" {
+		salary = j.SalaryCurrency + " " + salary
+	}
+	return salary
+}
+
+// formatAmount formats a whole amount with thousands separators
+func formatAmount(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + digits
+}
+
+// ValidateCompensation checks the salary range and that the work mode is empty or one of
+// GetWorkModes, returning the name of the invalid field along with the error
+func (j *JobApplication) ValidateCompensation() (string, error) {
+	if j.SalaryMin != nil && *j.SalaryMin < 0 {
+		return "salary_min", errors.New("salary_min must not be negative")
+	}
+	if j.SalaryMax != nil && *j.SalaryMax < 0 {
+		return "salary_max", errors.New("salary_max must not be negative")
+	}
+	if j.SalaryMin != nil && j.SalaryMax != nil && *j.SalaryMin > *j.SalaryMax {
+		return "salary_max", errors.New("salary_max must not be less than salary_min")
+	}
+	if mode, ok := ParseWorkMode(j.WorkMode); !ok || mode != j.WorkMode {
+		return "work_mode", fmt.Errorf("work_mode must be one of %s", strings.Join(GetWorkModes(), ", "))
 	}
+	return "", nil
 }
@@ -0,0 +1,108 @@
+package models
+
+// Status categories group statuses by what they mean for an application
+const (
+	// CategoryActive statuses are still in progress
+	CategoryActive = "active"
+	// CategoryClosed statuses ended without an offer
+	CategoryClosed = "closed"
+	// CategorySuccess statuses ended with an offer
+	CategorySuccess = "success"
+)
+
+// GetStatusCategories returns the categories a status can belong to
+func GetStatusCategories() []string {
+	return []string{CategoryActive, CategoryClosed, CategorySuccess}
+}
+
+// IsValidStatusCategory reports whether category is one of GetStatusCategories
+func IsValidStatusCategory(category string) bool {
+	for _, c := range GetStatusCategories() {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Status roles mark the statuses the app itself acts on, so they keep working when
+// statuses are renamed
+const (
+	// RoleRejection statuses mean the company turned the application down
+	RoleRejection = "rejection"
+	// RoleNoResponse is the status silent applications are moved to; only one status has it
+	RoleNoResponse = "no_response"
+)
+
+// GetStatusRoles returns the roles a status can have, besides none
+func GetStatusRoles() []string {
+	return []string{RoleRejection, RoleNoResponse}
+}
+
+// IsValidStatusRole reports whether role is empty or one of GetStatusRoles
+func IsValidStatusRole(role string) bool {
+	if role == "" {
+		return true
+	}
+	for _, r := range GetStatusRoles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Status is a configurable step of the application workflow
+type Status struct {
+	ID       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Position int    `json:"position" db:"position"`
+	// Color is a CSS hex color such as #3498db
+	Color    string `json:"color" db:"color"`
+	Category string `json:"category" db:"category"`
+	// Role is one of GetStatusRoles, or empty
+	Role string `json:"role" db:"role"`
+	// Transitions lists the statuses an application may move to from this one.
+	// An empty list allows moving to any status.
+	Transitions []string `json:"transitions" db:"-"`
+	// ApplicationCount is the number of the user's applications currently in this status
+	ApplicationCount int `json:"application_count" db:"-"`
+}
+
+// CanTransitionTo reports whether an application in this status may move to the named status
+func (s *Status) CanTransitionTo(name string) bool {
+	return len(s.Transitions) == 0 || name == s.Name || s.HasTransition(name)
+}
+
+// HasTransition reports whether the named status is explicitly listed in Transitions
+func (s *Status) HasTransition(name string) bool {
+	for _, transition := range s.Transitions {
+		if transition == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultStatus describes a status created for new databases
+type DefaultStatus struct {
+	Name     string
+	Color    string
+	Category string
+	Role     string
+}
+
+// GetDefaultStatuses returns the workflow a new database starts with, in order
+func GetDefaultStatuses() []DefaultStatus {
+	return []DefaultStatus{
+		{StatusApplied, "#3498db", CategoryActive, ""},
+		{StatusInReview, "#f39c12", CategoryActive, ""},
+		{StatusPhoneScreen, "#9b59b6", CategoryActive, ""},
+		{StatusInterview, "#e67e22", CategoryActive, ""},
+		{StatusTechnical, "#8e44ad", CategoryActive, ""},
+		{StatusOffer, "#27ae60", CategorySuccess, ""},
+		{StatusRejected, "#e74c3c", CategoryClosed, RoleRejection},
+		{StatusWithdrawn, "#95a5a6", CategoryClosed, ""},
+		{StatusNoResponse, "#7f8c8d", CategoryClosed, RoleNoResponse},
+	}
+}
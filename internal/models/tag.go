@@ -0,0 +1,34 @@
+package models
+
+import "strings"
+
+// Tag is a free-form label such as a tech stack, "referral" or "remote".
+// A tag can be attached to any number of job applications.
+type Tag struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	// ApplicationCount is the number of applications with this tag
+	ApplicationCount int `json:"application_count" db:"-"`
+}
+
+// ParseTags splits a comma-separated list of tags, as typed into forms and CSV files,
+// and normalizes the result with NormalizeTags
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.Split(s, ","))
+}
+
+// NormalizeTags trims tags and a leading #, and drops empty tags and case-insensitive
+// duplicates, keeping the first spelling of each
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// MaxUsernameLength is the longest username accepted
+const MaxUsernameLength = 64
+
+// usernamePattern matches the characters allowed in usernames
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._@-]+$`)
+
+// User is an account with its own job applications, contacts, companies and tags.
+// Statuses and follow-up rules are shared by everyone and managed by admins.
+type User struct {
+	ID           int       `json:"id" db:"id"`
+	Username     string    `json:"username" db:"username"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	IsAdmin      bool      `json:"is_admin" db:"is_admin"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	// ApplicationCount is the number of applications the user has tracked
+	ApplicationCount int `json:"application_count" db:"-"`
+}
+
+// HasPassword reports whether the user can log in with a password
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
+// ValidateUsername checks that a username is non-empty, not too long and made of
+// letters, digits and . _ @ -
+func ValidateUsername(username string) error {
+	switch {
+	case username == "":
+		return errors.New("username is required")
+	case len(username) > MaxUsernameLength:
+		return errors.New("username must be at most 64 characters")
+	case !usernamePattern.MatchString(username):
+		return errors.New("username may only contain letters, digits and . _ @ -")
+	}
+	return nil
+}
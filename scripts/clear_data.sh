@@ -64,6 +64,7 @@ clear_data() {
     if check_database; then
         print_status "Removing database file..."
         rm -f ./data/jobs.db
+        rm -rf ./data/attachments
         print_success "Database cleared successfully"
     else
         print_warning "No database file found to clear"